@@ -0,0 +1,268 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type columnType struct {
+	Base      string
+	Length    int64
+	Precision int64
+	Scale     int64
+}
+
+var (
+	typeParameters = regexp.MustCompile(`\s*\(([^)]*)\)`)
+	typeNumbers    = regexp.MustCompile(`-?\d+`)
+)
+
+// parseColumnType splits types like VARCHAR2(100 CHAR) or NUMBER(10,2) into the base type and its sizes
+func parseColumnType(sqlType string) (typ columnType) {
+	sqlType = strings.ToUpper(strings.TrimSpace(sqlType))
+	if parameters := typeParameters.FindStringSubmatch(sqlType); parameters != nil {
+		if numbers := typeNumbers.FindAllString(parameters[1], 2); len(numbers) > 0 {
+			typ.Length, _ = strconv.ParseInt(numbers[0], 10, 64)
+			typ.Precision = typ.Length
+			if len(numbers) > 1 {
+				typ.Scale, _ = strconv.ParseInt(numbers[1], 10, 64)
+			}
+		}
+	}
+	typ.Base = strings.Join(strings.Fields(typeParameters.ReplaceAllString(sqlType, "")), " ")
+
+	switch typ.Base {
+	case "INTEGER", "INT", "SMALLINT":
+		typ.Base, typ.Precision, typ.Scale = "NUMBER", 38, 0
+	case "VARCHAR":
+		typ.Base = "VARCHAR2"
+	}
+	return
+}
+
+func isLOBType(base string) bool {
+	return base == "CLOB" || base == "NCLOB" || base == "BLOB"
+}
+
+func isCharacterType(base string) bool {
+	return base == "VARCHAR2" || base == "CHAR" || base == "NVARCHAR2" || base == "NCHAR"
+}
+
+// changesInPlace reports whether MODIFY can turn current into target while the column holds data
+func (current columnType) changesInPlace(target columnType) bool {
+	switch {
+	case current.Base == target.Base && isCharacterType(current.Base), current.Base == target.Base && current.Base == "RAW":
+		return target.Length == 0 || target.Length >= current.Length
+	case isCharacterType(current.Base) && isCharacterType(target.Base):
+		// CHAR and VARCHAR2 convert into each other, but not into their national counterparts
+		return strings.HasPrefix(current.Base, "N") == strings.HasPrefix(target.Base, "N") && target.Length >= current.Length
+	case current.Base == "NUMBER" && target.Base == "NUMBER":
+		return current.Scale == target.Scale && (current.Precision == 0 || target.Precision == 0 || target.Precision >= current.Precision)
+	case current.Base == "DATE" && target.Base == "TIMESTAMP":
+		return true
+	}
+	return current.Base == target.Base
+}
+
+func (m Migrator) currentColumnTypeOf(stmt *gorm.Statement, field *schema.Field) (typ columnType, err error) {
+	var (
+		dataType                     string
+		charLength                   int64
+		dataLength, precision, scale sql.NullInt64
+	)
+	if err = m.DB.Raw(
+		"SELECT DATA_TYPE, CHAR_LENGTH, DATA_LENGTH, DATA_PRECISION, DATA_SCALE FROM USER_TAB_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?",
+		ConvertNameToFormat(stmt.Table), ConvertNameToFormat(field.DBName),
+	).Row().Scan(&dataType, &charLength, &dataLength, &precision, &scale); err != nil {
+		return
+	}
+
+	typ = parseColumnType(dataType)
+	typ.Length, typ.Precision, typ.Scale = charLength, precision.Int64, scale.Int64
+	if typ.Length == 0 {
+		typ.Length = dataLength.Int64
+	}
+	return
+}
+
+// needsRebuild reports whether the type change of field can't be done by MODIFY, as the column holds data
+// Oracle won't convert, or it's a LOB, which MODIFY never converts
+func (m Migrator) needsRebuild(stmt *gorm.Statement, field *schema.Field) (bool, error) {
+	current, err := m.currentColumnTypeOf(stmt, field)
+	if err != nil {
+		return false, err
+	}
+
+	target := parseColumnType(m.DataTypeOf(field))
+	if current.changesInPlace(target) {
+		return false, nil
+	}
+	if isLOBType(current.Base) || isLOBType(target.Base) {
+		return true, nil
+	}
+
+	var count int64
+	err = m.DB.Raw(
+		"SELECT COUNT(*) FROM ? WHERE ? IS NOT NULL AND ROWNUM = 1", clause.Table{Name: stmt.Table}, clause.Column{Name: field.DBName},
+	).Row().Scan(&count)
+	return count > 0, err
+}
+
+// conversionOf is the expression copying the old column into the new type, `oracle:"using(expr)"` overrides it
+func (m Migrator) conversionOf(stmt *gorm.Statement, field *schema.Field) string {
+	if using := columnOptionOf(field).Using; using != "" {
+		return using
+	}
+
+	column, sqlType := stmt.Quote(field.DBName), m.DataTypeOf(field)
+	switch parseColumnType(sqlType).Base {
+	case "CLOB":
+		return "TO_CLOB(" + column + ")"
+	case "NCLOB":
+		return "TO_NCLOB(" + column + ")"
+	case "BLOB":
+		return "TO_BLOB(" + column + ")"
+	}
+	return "CAST(" + column + " AS " + sqlType + ")"
+}
+
+// dependentDDLOf keeps the DDL of the indexes and constraints on the column, and of the foreign keys referencing it,
+// to restore them once the column is rebuilt. GET_DDL returns a CLOB, which godror fetches whole as a string
+func (m Migrator) dependentDDLOf(stmt *gorm.Statement, field *schema.Field) (ddl []string, err error) {
+	table, column := ConvertNameToFormat(stmt.Table), ConvertNameToFormat(field.DBName)
+
+	if ddl, err = m.appendDDL(ddl,
+		"SELECT DBMS_METADATA.GET_DDL('INDEX', i.INDEX_NAME) FROM USER_INDEXES i "+
+			"WHERE i.TABLE_NAME = ? AND i.INDEX_NAME IN (SELECT INDEX_NAME FROM USER_IND_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?) "+
+			"AND i.INDEX_NAME NOT IN (SELECT INDEX_NAME FROM USER_CONSTRAINTS WHERE TABLE_NAME = ? AND INDEX_NAME IS NOT NULL) "+
+			"ORDER BY i.INDEX_NAME",
+		table, table, column, table,
+	); err != nil {
+		return
+	}
+
+	// NOT NULL constraints come back with the column definition
+	if ddl, err = m.appendDDL(ddl,
+		"SELECT DBMS_METADATA.GET_DDL(DECODE(c.CONSTRAINT_TYPE, 'R', 'REF_CONSTRAINT', 'CONSTRAINT'), c.CONSTRAINT_NAME) "+
+			"FROM USER_CONSTRAINTS c "+
+			"WHERE c.TABLE_NAME = ? AND c.CONSTRAINT_NAME IN (SELECT CONSTRAINT_NAME FROM USER_CONS_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?) "+
+			"AND NOT (c.CONSTRAINT_TYPE = 'C' AND c.GENERATED = 'GENERATED NAME') "+
+			"ORDER BY DECODE(c.CONSTRAINT_TYPE, 'P', 1, 'U', 2, 'C', 3, 4), c.CONSTRAINT_NAME",
+		table, table, column,
+	); err != nil {
+		return
+	}
+
+	return m.appendDDL(ddl,
+		"SELECT DBMS_METADATA.GET_DDL('REF_CONSTRAINT', r.CONSTRAINT_NAME) FROM USER_CONSTRAINTS r "+
+			"JOIN USER_CONSTRAINTS c ON c.CONSTRAINT_NAME = r.R_CONSTRAINT_NAME AND c.OWNER = r.R_OWNER "+
+			"WHERE r.CONSTRAINT_TYPE = 'R' AND r.TABLE_NAME <> ? AND c.TABLE_NAME = ? "+
+			"AND c.CONSTRAINT_NAME IN (SELECT CONSTRAINT_NAME FROM USER_CONS_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?) "+
+			"ORDER BY r.CONSTRAINT_NAME",
+		table, table, table, column,
+	)
+}
+
+// appendDDL appends the statements query returns to ddl
+func (m Migrator) appendDDL(ddl []string, query string, values ...interface{}) ([]string, error) {
+	rows, err := m.DB.Raw(query, values...).Rows()
+	if err != nil {
+		return ddl, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var statement string
+		if err := rows.Scan(&statement); err != nil {
+			return ddl, err
+		}
+		ddl = append(ddl, statement)
+	}
+	return ddl, rows.Err()
+}
+
+// rebuildColumn changes the type of a populated column through a new column: add it, copy the data over with a conversion,
+// drop the old column and rename the new one, then restore NOT NULL, the default, indexes and constraints.
+// As DDL commits, a failed step after the copy leaves the steps before it in place, and the column moves to the end of the table
+func (m Migrator) rebuildColumn(stmt *gorm.Statement, field *schema.Field) error {
+	dependentDDL, err := m.dependentDDLOf(stmt, field)
+	if err != nil {
+		return err
+	}
+
+	var (
+		table     = clause.Table{Name: stmt.Table}
+		column    = clause.Column{Name: field.DBName}
+		temporary = clause.Column{Name: limitIdentifier(ConvertNameToFormat(field.DBName) + "$TMP")}
+	)
+
+	storage, _, err := m.storageOption()
+	if err != nil {
+		return err
+	}
+
+	definition := m.DataTypeOf(field)
+	if option := columnOptionOf(field); option.Invisible {
+		definition += " INVISIBLE"
+	}
+
+	// the LOB storage belongs to the new column, which takes the old column's name only after the copy
+	temporaryField := *field
+	temporaryField.DBName = temporary.Name
+	lob := storage.lobClause(m, stmt, &temporaryField)
+
+	if err := m.DB.Exec("ALTER TABLE ? ADD (? "+definition+")"+lob, table, temporary).Error; err != nil {
+		return err
+	}
+
+	if err := m.DB.Exec("UPDATE ? SET ? = "+m.conversionOf(stmt, field), table, temporary).Error; err != nil {
+		// nothing is lost yet, leave the table as it was so the rebuild can run again
+		if dropErr := m.DB.Exec("ALTER TABLE ? DROP COLUMN ?", table, temporary).Error; dropErr != nil {
+			return fmt.Errorf("%w, and failed to drop %s: %v", err, temporary.Name, dropErr)
+		}
+		return err
+	}
+
+	if err := m.DB.Exec("ALTER TABLE ? DROP COLUMN ? CASCADE CONSTRAINTS", table, column).Error; err != nil {
+		return err
+	}
+
+	if err := m.DB.Exec("ALTER TABLE ? RENAME COLUMN ? TO ?", table, temporary, column).Error; err != nil {
+		return err
+	}
+
+	var modifications []string
+	if field.HasDefaultValue && field.DefaultValueInterface != nil {
+		modifications = append(modifications, "DEFAULT "+literalOf(field.DefaultValueInterface))
+	} else if field.HasDefaultValue && field.DefaultValue != "" && field.DefaultValue != "(-)" {
+		modifications = append(modifications, "DEFAULT "+field.DefaultValue)
+	}
+	if field.NotNull {
+		modifications = append(modifications, "NOT NULL")
+	}
+	if len(modifications) > 0 {
+		if err := m.DB.Exec("ALTER TABLE ? MODIFY (? "+strings.Join(modifications, " ")+")", table, column).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, ddl := range dependentDDL {
+		if err := m.DB.Exec(strings.TrimSpace(ddl)).Error; err != nil {
+			return fmt.Errorf("failed to restore %q: %w", strings.TrimSpace(ddl), err)
+		}
+	}
+	return nil
+}
+
+// PreviewAlterColumn returns the statements AlterColumn would run, without running them
+func (m Migrator) PreviewAlterColumn(value interface{}, field string) (*Plan, error) {
+	plan := &Plan{}
+	return plan, m.recording(plan).AlterColumn(value, field)
+}
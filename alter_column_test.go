@@ -0,0 +1,127 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type rebuiltRow struct {
+	ID   uint
+	Code int `gorm:"index"`
+}
+
+// rebuiltQuery has CODE hold VARCHAR2 data, with an index whose DDL is longer than 4000 bytes
+func rebuiltQuery(indexDDL string) func(query string, args []interface{}) fakeResult {
+	return func(query string, args []interface{}) fakeResult {
+		switch {
+		case hasPrefixFold(query, "SELECT COUNT(*)"):
+			return countResult(1)
+		case hasPrefixFold(query, "SELECT DATA_TYPE"):
+			return fakeResult{
+				Columns: []string{"DATA_TYPE", "CHAR_LENGTH", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE"},
+				Rows:    [][]driver.Value{{"VARCHAR2", int64(100), int64(100), nil, nil}},
+			}
+		case hasPrefixFold(query, "SELECT DBMS_METADATA.GET_DDL('INDEX'"):
+			return fakeResult{Columns: []string{"DDL"}, Rows: [][]driver.Value{{indexDDL}}}
+		}
+		return fakeResult{}
+	}
+}
+
+func TestRebuildColumnRestoresFullDDL(t *testing.T) {
+	indexDDL := "CREATE INDEX IDX_REBUILT_ROWS_CODE ON REBUILT_ROWS (CODE)" + strings.Repeat(" ", 5000) + "TABLESPACE USERS"
+	db, fake := openFakeDB(t, Config{}, rebuiltQuery(indexDDL))
+
+	if err := db.Migrator().AlterColumn(&rebuiltRow{}, "Code"); err != nil {
+		t.Fatalf("failed to alter column: %v", err)
+	}
+
+	sql := fake.SQL()
+	for _, statement := range sql {
+		if strings.Contains(statement, "DBMS_LOB.SUBSTR") {
+			t.Errorf("expected the DDL to be fetched whole, got %q", statement)
+		}
+	}
+	if want := strings.TrimSpace(indexDDL); sql[len(sql)-1] != want {
+		t.Errorf("expected the index to be restored with its full DDL, got %q", sql[len(sql)-1])
+	}
+}
+
+func TestRebuildColumnDropsTemporaryColumnOnFailedCopy(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, rebuiltQuery("CREATE INDEX IDX_REBUILT_ROWS_CODE ON REBUILT_ROWS (CODE)"))
+	invalidNumber := errors.New("ORA-01722: invalid number")
+	fake.Exec = func(query string) error {
+		if hasPrefixFold(query, "UPDATE") {
+			return invalidNumber
+		}
+		return nil
+	}
+
+	if err := db.Migrator().AlterColumn(&rebuiltRow{}, "Code"); !errors.Is(err, invalidNumber) {
+		t.Fatalf("expected the copy to fail, got %v", err)
+	}
+
+	var ddl []string
+	for _, statement := range fake.SQL() {
+		if !hasPrefixFold(statement, "SELECT") {
+			ddl = append(ddl, statement)
+		}
+	}
+	if len(ddl) != 3 || ddl[2] != "ALTER TABLE REBUILT_ROWS DROP COLUMN CODE$TMP" {
+		t.Errorf("expected the temporary column to be dropped, got %q", ddl)
+	}
+}
+
+type noteRow struct {
+	ID    uint
+	Notes string `gorm:"type:CLOB"`
+}
+
+func TestRebuildColumnAppliesLOBStorage(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, rebuiltQuery("CREATE INDEX IDX_NOTE_ROWS_NOTES ON NOTE_ROWS (NOTES)"))
+
+	m := db.Migrator().(Migrator).WithStorage(StorageOption{LOBStorage: "securefile", LOBTablespace: "LOBS"})
+	if err := m.AlterColumn(&noteRow{}, "Notes"); err != nil {
+		t.Fatalf("failed to alter column: %v", err)
+	}
+
+	want := "ALTER TABLE NOTE_ROWS ADD (NOTES$TMP CLOB) LOB (NOTES$TMP) STORE AS SECUREFILE (TABLESPACE LOBS)"
+	for _, statement := range fake.SQL() {
+		if hasPrefixFold(statement, "ALTER TABLE NOTE_ROWS ADD") {
+			if statement != want {
+				t.Errorf("expected %q, got %q", want, statement)
+			}
+			return
+		}
+	}
+	t.Errorf("expected the temporary column to be added, got %q", fake.SQL())
+}
+
+type longNamedRow struct {
+	ID                          uint
+	AccountingReferenceCategory int
+}
+
+func TestRebuildColumnShortensTemporaryName(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, rebuiltQuery(
+		"CREATE INDEX IDX_LONG_NAMED_ROWS_CATEGORY ON LONG_NAMED_ROWS (ACCOUNTING_REFERENCE_CATEGORY)",
+	))
+
+	if err := db.Migrator().AlterColumn(&longNamedRow{}, "AccountingReferenceCategory"); err != nil {
+		t.Fatalf("failed to alter column: %v", err)
+	}
+
+	temporary := "ACCOUNTING_REFERENCE__" + hashOf("ACCOUNTING_REFERENCE_CATEGORY$TMP")
+	if len(temporary) > 30 {
+		t.Fatalf("expected the temporary name to fit 30 bytes, got %q", temporary)
+	}
+	want := "ALTER TABLE LONG_NAMED_ROWS RENAME COLUMN " + temporary + " TO ACCOUNTING_REFERENCE_CATEGORY"
+	for _, statement := range fake.SQL() {
+		if statement == want {
+			return
+		}
+	}
+	t.Errorf("expected %q, got %q", want, fake.SQL())
+}
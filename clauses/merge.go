@@ -14,8 +14,9 @@ func (merge Merge) Name() string {
 	return "MERGE"
 }
 
+// MergeDefaultExcludeName is the alias of the merged values, the table clause.AssignmentColumns refers to
 func MergeDefaultExcludeName() string {
-	return "exclude"
+	return "excluded"
 }
 
 // Build build from clause
@@ -35,7 +36,7 @@ func (merge Merge) Build(builder clause.Builder) {
 	builder.WriteString(" ON (")
 	for idx, on := range merge.On {
 		if idx > 0 {
-			builder.WriteString(", ")
+			builder.WriteString(" AND ")
 		}
 		on.Build(builder)
 	}
@@ -15,11 +15,8 @@ func (w WhenMatched) Name() string {
 
 func (w WhenMatched) Build(builder clause.Builder) {
 	if len(w.Set) > 0 {
-		builder.WriteString(" THEN")
-		builder.WriteString(" UPDATE ")
-		builder.WriteString(w.Name())
-		builder.WriteByte(' ')
-		w.Build(builder)
+		builder.WriteString("THEN UPDATE SET ")
+		w.Set.Build(builder)
 
 		buildWhere := func(where clause.Where) {
 			builder.WriteString(where.Name())
@@ -28,6 +25,7 @@ func (w WhenMatched) Build(builder clause.Builder) {
 		}
 
 		if len(w.Where.Exprs) > 0 {
+			builder.WriteByte(' ')
 			buildWhere(w.Where)
 		}
 
@@ -37,3 +35,9 @@ func (w WhenMatched) Build(builder clause.Builder) {
 		}
 	}
 }
+
+// MergeClause keeps the clause itself, instead of the embedded Set
+func (w WhenMatched) MergeClause(clause *clause.Clause) {
+	clause.Name = w.Name()
+	clause.Expression = w
+}
@@ -19,14 +19,20 @@ func (w WhenNotMatched) Build(builder clause.Builder) {
 			panic("cannot insert more than one rows due to Oracle SQL language restriction")
 		}
 
-		builder.WriteString(" THEN")
-		builder.WriteString(" INSERT ")
-		w.Build(builder)
+		builder.WriteString("THEN INSERT ")
+		w.Values.Build(builder)
 
 		if len(w.Where.Exprs) > 0 {
+			builder.WriteByte(' ')
 			builder.WriteString(w.Where.Name())
 			builder.WriteByte(' ')
 			w.Where.Build(builder)
 		}
 	}
 }
+
+// MergeClause keeps the clause itself, instead of the embedded Values
+func (w WhenNotMatched) MergeClause(clause *clause.Clause) {
+	clause.Name = w.Name()
+	clause.Expression = w
+}
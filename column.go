@@ -0,0 +1,60 @@
+package oracle
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// columnOption is parsed from the `oracle` tag, e.g.
+//
+//	FullName string `oracle:"virtual(first_name || ' ' || last_name)"`
+//	Secret   string `oracle:"invisible"`
+//	Code     int    `oracle:"using(TO_NUMBER(code DEFAULT NULL ON CONVERSION ERROR))"`
+type columnOption struct {
+	// Virtual is the expression of a GENERATED ALWAYS AS (...) VIRTUAL column
+	Virtual string
+	// Invisible columns are left out of SELECT *, so queries into the model name them
+	Invisible bool
+	// Using converts the old values when AlterColumn rebuilds the column with another type
+	Using string
+}
+
+func columnOptionOf(field *schema.Field) (option columnOption) {
+	for _, setting := range strings.Split(field.Tag.Get("oracle"), ";") {
+		setting = strings.TrimSpace(setting)
+		switch upper := strings.ToUpper(setting); {
+		case strings.HasPrefix(upper, "VIRTUAL(") && strings.HasSuffix(upper, ")"):
+			option.Virtual = strings.TrimSpace(setting[len("VIRTUAL(") : len(setting)-1])
+		case strings.HasPrefix(upper, "USING(") && strings.HasSuffix(upper, ")"):
+			option.Using = strings.TrimSpace(setting[len("USING(") : len(setting)-1])
+		case upper == "INVISIBLE":
+			option.Invisible = true
+		}
+	}
+	return
+}
+
+func isVirtualColumn(field *schema.Field) bool {
+	return columnOptionOf(field).Virtual != ""
+}
+
+// OmitVirtualColumns leaves virtual columns out of INSERT and UPDATE, they are still read by queries
+func OmitVirtualColumns(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	var virtual []string
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName != "" && isVirtualColumn(field) {
+			virtual = append(virtual, field.Name)
+		}
+	}
+
+	if len(virtual) > 0 {
+		// the omits may be shared with the statement this one was cloned from
+		db.Statement.Omits = append(append([]string{}, db.Statement.Omits...), virtual...)
+	}
+}
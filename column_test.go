@@ -0,0 +1,117 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type hiddenColumns struct {
+	ID       uint
+	Name     string `gorm:"size:100"`
+	Secret   string `gorm:"size:100" oracle:"invisible"`
+	FullName string `gorm:"size:200" oracle:"virtual(name || secret)"`
+}
+
+func TestQuerySelectsInvisibleColumns(t *testing.T) {
+	db, _ := openFakeDB(t, Config{}, nil)
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	tests := []struct {
+		name string
+		run  func(tx *gorm.DB) *gorm.DB
+		want string
+	}{
+		{
+			name: "find",
+			run:  func(tx *gorm.DB) *gorm.DB { return tx.Find(&[]hiddenColumns{}) },
+			want: "SELECT ID,NAME,SECRET,FULL_NAME FROM HIDDEN_COLUMNS",
+		},
+		{
+			name: "omit",
+			run:  func(tx *gorm.DB) *gorm.DB { return tx.Omit("FullName").Find(&hiddenColumns{}) },
+			want: "SELECT ID,NAME,SECRET FROM HIDDEN_COLUMNS",
+		},
+		{
+			name: "select",
+			run:  func(tx *gorm.DB) *gorm.DB { return tx.Select("Name").Find(&[]hiddenColumns{}) },
+			want: "SELECT NAME FROM HIDDEN_COLUMNS",
+		},
+		{
+			name: "other model",
+			run:  func(tx *gorm.DB) *gorm.DB { return tx.Model(&hiddenColumns{}).Find(&[]map[string]interface{}{}) },
+			want: "SELECT * FROM HIDDEN_COLUMNS",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := test.run(dryRun)
+			if tx.Error != nil {
+				t.Fatalf("failed to build SQL: %v", tx.Error)
+			}
+			if got := tx.Statement.SQL.String(); got != test.want {
+				t.Errorf("got SQL %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAlterColumnKeepsVirtualColumns(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+		switch {
+		case hasPrefixFold(query, "SELECT COUNT(*)"):
+			return countResult(1)
+		case hasPrefixFold(query, "SELECT DATA_TYPE"):
+			return fakeResult{
+				Columns: []string{"DATA_TYPE", "CHAR_LENGTH", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE"},
+				Rows:    [][]driver.Value{{"NUMBER", int64(0), int64(22), nil, nil}},
+			}
+		}
+		return fakeResult{}
+	})
+
+	if err := db.Migrator().AlterColumn(&hiddenColumns{}, "FullName"); err != nil {
+		t.Fatalf("failed to alter column: %v", err)
+	}
+
+	for _, sql := range fake.SQL() {
+		if strings.Contains(sql, "$TMP") || hasPrefixFold(sql, "SELECT DATA_TYPE") {
+			t.Errorf("expected the virtual column not to be rebuilt, got %q", fake.SQL())
+			break
+		}
+	}
+	if sql := fake.SQL(); len(sql) == 0 || !hasPrefixFold(sql[len(sql)-1], "ALTER TABLE HIDDEN_COLUMNS MODIFY FULL_NAME ") ||
+		!strings.HasSuffix(sql[len(sql)-1], "VIRTUAL") {
+		t.Errorf("expected the virtual column to be modified, got %q", sql)
+	}
+}
+
+func TestAutoMigrateKeepsInvisibleColumns(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, func(query string, args []interface{}) fakeResult {
+		switch {
+		case hasPrefixFold(query, "SELECT COUNT(*) FROM USER_TABLES"):
+			return countResult(1)
+		case hasPrefixFold(query, "SELECT COLUMN_NAME FROM USER_TAB_COLUMNS"):
+			return fakeResult{Columns: []string{"COLUMN_NAME"}, Rows: [][]driver.Value{{"ID"}, {"NAME"}, {"FULL_NAME"}, {"SECRET"}}}
+		case hasPrefixFold(query, "SELECT ID,NAME,FULL_NAME,SECRET FROM HIDDEN_COLUMNS"):
+			return fakeResult{Columns: []string{"ID", "NAME", "FULL_NAME", "SECRET"}}
+		case hasPrefixFold(query, "SELECT * FROM HIDDEN_COLUMNS"):
+			// SELECT * leaves the invisible column out
+			return fakeResult{Columns: []string{"ID", "NAME", "FULL_NAME"}}
+		}
+		return fakeResult{}
+	})
+
+	if err := db.AutoMigrate(&hiddenColumns{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	for _, sql := range fake.SQL() {
+		if !hasPrefixFold(sql, "SELECT") {
+			t.Errorf("expected the existing table to be left alone, got %q", sql)
+		}
+	}
+}
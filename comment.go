@@ -0,0 +1,112 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// TableCommenter is implemented by models commenting their tables, e.g.
+//
+//	func (User) TableComment() string {
+//		return "registered users"
+//	}
+//
+// column comments come from the `comment` tag
+type TableCommenter interface {
+	TableComment() string
+}
+
+// tableCommentOf prefers the TableComment of the model over the gorm:table_comment setting
+func (m Migrator) tableCommentOf(stmt *gorm.Statement) (string, bool) {
+	if commenter, ok := reflect.New(stmt.Schema.ModelType).Interface().(TableCommenter); ok {
+		return commenter.TableComment(), true
+	}
+	if comment, ok := m.DB.Get("gorm:table_comment"); ok {
+		return fmt.Sprint(comment), true
+	}
+	return "", false
+}
+
+func (m Migrator) commentOnTable(stmt *gorm.Statement, comment string) error {
+	return m.DB.Exec(
+		"COMMENT ON TABLE ? IS ?",
+		clause.Table{Name: stmt.Table}, clause.Expr{SQL: literalOf(comment)},
+	).Error
+}
+
+func (m Migrator) commentOnColumn(stmt *gorm.Statement, field *schema.Field) error {
+	if field.Comment == "" {
+		return nil
+	}
+
+	return m.DB.Exec(
+		"COMMENT ON COLUMN ?.? IS ?",
+		clause.Table{Name: stmt.Table}, clause.Column{Name: field.DBName}, clause.Expr{SQL: literalOf(field.Comment)},
+	).Error
+}
+
+// migrateComments updates the table and column comments differing from USER_TAB_COMMENTS and USER_COL_COMMENTS,
+// comments the model doesn't declare are left alone
+func (m Migrator) migrateComments(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if option, ok := temporaryTableOf(stmt); ok && option.Private {
+			return nil
+		}
+
+		table := ConvertNameToFormat(stmt.Table)
+		if comment, ok := m.tableCommentOf(stmt); ok {
+			var current sql.NullString
+			switch err := m.DB.Raw(
+				"SELECT COMMENTS FROM USER_TAB_COMMENTS WHERE TABLE_NAME = ?", table,
+			).Row().Scan(&current); err {
+			case nil:
+				if current.String != comment {
+					if err := m.commentOnTable(stmt, comment); err != nil {
+						return err
+					}
+				}
+			case sql.ErrNoRows:
+				// the table was just created by a plan, CreateTable commented it
+			default:
+				return err
+			}
+		}
+
+		rows, err := m.DB.Raw("SELECT COLUMN_NAME, COMMENTS FROM USER_COL_COMMENTS WHERE TABLE_NAME = ?", table).Rows()
+		if err != nil {
+			return err
+		}
+
+		comments := map[string]string{}
+		for rows.Next() {
+			var (
+				column  string
+				comment sql.NullString
+			)
+			if err := rows.Scan(&column, &comment); err != nil {
+				rows.Close()
+				return err
+			}
+			comments[column] = comment.String
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, dbName := range stmt.Schema.DBNames {
+			field := stmt.Schema.FieldsByDBName[dbName]
+			if current, ok := comments[ConvertNameToFormat(dbName)]; ok && field.Comment != "" && current != field.Comment {
+				if err := m.commentOnColumn(stmt, field); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+type commentedRow struct {
+	ID   uint
+	Name string `gorm:"size:100;comment:full name"`
+	Code string `gorm:"size:10;comment:short code"`
+}
+
+func (commentedRow) TableComment() string {
+	return "commented rows"
+}
+
+// commentedQuery answers the dictionary with tableComment and the NAME and CODE comments,
+// an empty tableComment has the table missing from USER_TAB_COMMENTS
+func commentedQuery(tableComment, nameComment, codeComment string) func(query string, args []interface{}) fakeResult {
+	return func(query string, args []interface{}) fakeResult {
+		switch {
+		case hasPrefixFold(query, "SELECT COUNT(*)"):
+			return countResult(0)
+		case hasPrefixFold(query, "SELECT COMMENTS FROM USER_TAB_COMMENTS") && tableComment != "":
+			return fakeResult{Columns: []string{"COMMENTS"}, Rows: [][]driver.Value{{tableComment}}}
+		case hasPrefixFold(query, "SELECT COLUMN_NAME, COMMENTS FROM USER_COL_COMMENTS") && tableComment != "":
+			return fakeResult{
+				Columns: []string{"COLUMN_NAME", "COMMENTS"},
+				Rows:    [][]driver.Value{{"ID", nil}, {"NAME", nameComment}, {"CODE", codeComment}},
+			}
+		}
+		return fakeResult{}
+	}
+}
+
+func commentDDLOf(statements []string) (ddl []string) {
+	for _, statement := range statements {
+		if hasPrefixFold(statement, "COMMENT ON") {
+			ddl = append(ddl, statement)
+		}
+	}
+	return
+}
+
+func TestMigrateCommentsUpdatesChangedComments(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, commentedQuery("old rows", "old name", "short code"))
+
+	if err := db.Migrator().(Migrator).migrateComments(&commentedRow{}); err != nil {
+		t.Fatalf("failed to migrate comments: %v", err)
+	}
+
+	want := []string{
+		"COMMENT ON TABLE COMMENTED_ROWS IS 'commented rows'",
+		"COMMENT ON COLUMN COMMENTED_ROWS.NAME IS 'full name'",
+	}
+	if ddl := commentDDLOf(fake.SQL()); strings.Join(ddl, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected the changed comments only, got %q", ddl)
+	}
+}
+
+func TestMigrateCommentsSkipsUnchangedComments(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, commentedQuery("commented rows", "full name", "short code"))
+
+	if err := db.Migrator().(Migrator).migrateComments(&commentedRow{}); err != nil {
+		t.Fatalf("failed to migrate comments: %v", err)
+	}
+
+	for _, statement := range fake.SQL() {
+		if !hasPrefixFold(statement, "SELECT") {
+			t.Errorf("expected no DDL for unchanged comments, got %q", statement)
+		}
+	}
+}
+
+func TestMigrateCommentsWithoutDictionaryRows(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, commentedQuery("", "", ""))
+
+	if err := db.Migrator().(Migrator).migrateComments(&commentedRow{}); err != nil {
+		t.Fatalf("expected a table missing from the dictionary to be skipped, got %v", err)
+	}
+	if ddl := commentDDLOf(fake.SQL()); len(ddl) != 0 {
+		t.Errorf("expected no comments, got %q", ddl)
+	}
+
+	// a plan doesn't create the table, the comments come from CreateTable alone
+	plan, err := db.Migrator().(Migrator).Plan(&commentedRow{})
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	want := []string{
+		"COMMENT ON TABLE COMMENTED_ROWS IS 'commented rows'",
+		"COMMENT ON COLUMN COMMENTED_ROWS.NAME IS 'full name'",
+		"COMMENT ON COLUMN COMMENTED_ROWS.CODE IS 'short code'",
+	}
+	if ddl := commentDDLOf(plan.Statements); strings.Join(ddl, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected each comment once, got %q", ddl)
+	}
+}
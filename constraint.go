@@ -0,0 +1,419 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	ConstraintPrimaryKey = "P"
+	ConstraintUnique     = "U"
+	ConstraintForeignKey = "R"
+	ConstraintCheck      = "C"
+)
+
+// ConstraintState is declared on the field owning a check or foreign key constraint,
+// e.g. `gorm:"check:age > 0;constraintState:deferred,novalidate"`
+type ConstraintState struct {
+	Deferrable        bool
+	InitiallyDeferred bool
+	Disabled          bool
+	NoValidate        bool
+}
+
+type ConstraintInfo struct {
+	Name string
+	// Type is P (primary key), U (unique), R (foreign key) or C (check and NOT NULL)
+	Type       string
+	Enabled    bool
+	Validated  bool
+	Deferrable bool
+	Deferred   bool
+}
+
+func constraintStateOf(field *schema.Field) (state ConstraintState, err error) {
+	setting, ok := field.TagSettings["CONSTRAINTSTATE"]
+	if !ok {
+		return
+	}
+
+	for _, entry := range strings.Split(setting, ",") {
+		switch key := strings.Join(strings.Fields(strings.ToUpper(entry)), " "); key {
+		case "DEFERRABLE":
+			state.Deferrable = true
+		case "DEFERRED", "INITIALLY DEFERRED":
+			state.Deferrable, state.InitiallyDeferred = true, true
+		case "DISABLE", "DISABLED":
+			state.Disabled = true
+		case "NOVALIDATE":
+			state.NoValidate = true
+		case "", "ENABLE", "VALIDATE", "IMMEDIATE", "INITIALLY IMMEDIATE":
+		default:
+			return state, fmt.Errorf("invalid constraint state %q of field %s, expect DEFERRABLE, DEFERRED, DISABLE or NOVALIDATE", entry, field.Name)
+		}
+	}
+	return
+}
+
+func (state ConstraintState) build() (sql string) {
+	if state.Deferrable {
+		sql += " DEFERRABLE"
+		if state.InitiallyDeferred {
+			sql += " INITIALLY DEFERRED"
+		}
+	}
+	if state.Disabled {
+		sql += " DISABLE"
+	}
+	if state.NoValidate {
+		sql += " NOVALIDATE"
+	}
+	return
+}
+
+func (m Migrator) checkConstraintOf(chk schema.Check) (string, []interface{}, error) {
+	state, err := constraintStateOf(chk.Field)
+	if err != nil {
+		return "", nil, err
+	}
+	return "CONSTRAINT ? CHECK (?)" + state.build(), []interface{}{clause.Column{Name: chk.Name}, clause.Expr{SQL: chk.Constraint}}, nil
+}
+
+// foreignKeyOf builds the foreign key without touching the schema, actions Oracle doesn't support are left out
+func (m Migrator) foreignKeyOf(constraint *schema.Constraint) (string, []interface{}, error) {
+	// warns about the ON UPDATE actions Oracle lacks
+	m.onUpdateOf(constraint)
+
+	state, err := m.foreignKeyStateOf(constraint)
+	if err != nil {
+		return "", nil, err
+	}
+
+	foreignKeySQL := "CONSTRAINT ? FOREIGN KEY ? REFERENCES ??"
+	if onDelete := m.onDeleteOf(constraint); onDelete != "" {
+		foreignKeySQL += " ON DELETE " + onDelete
+	}
+
+	var foreignKeys, references []interface{}
+	for _, field := range constraint.ForeignKeys {
+		foreignKeys = append(foreignKeys, clause.Column{Name: field.DBName})
+	}
+	for _, field := range constraint.References {
+		references = append(references, clause.Column{Name: field.DBName})
+	}
+
+	return foreignKeySQL + state.build(), []interface{}{
+		clause.Column{Name: constraint.Name}, foreignKeys, clause.Table{Name: constraint.ReferenceSchema.Table}, references,
+	}, nil
+}
+
+// foreignKeyStateOf is the state of the foreign key from its tag, an emulated ON UPDATE CASCADE needs it deferred
+func (m Migrator) foreignKeyStateOf(constraint *schema.Constraint) (ConstraintState, error) {
+	state, err := constraintStateOf(constraint.Field)
+	if err == nil && m.onUpdateCascadeOf(constraint) && !state.Deferrable {
+		state.Deferrable, state.InitiallyDeferred = true, true
+	}
+	return state, err
+}
+
+// sortedChecks orders the check constraints by name, so the generated DDL is stable
+func sortedChecks(stmt *gorm.Statement) (checks []schema.Check) {
+	for _, chk := range stmt.Schema.ParseCheckConstraints() {
+		checks = append(checks, chk)
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return
+}
+
+// constraintNameOf resolves a field name, check name or foreign key name of the model to the stored constraint name
+func (m Migrator) constraintNameOf(stmt *gorm.Statement, name string) string {
+	if stmt.Schema != nil {
+		if field := stmt.Schema.LookUpField(name); field != nil {
+			for _, chk := range stmt.Schema.ParseCheckConstraints() {
+				if chk.Field == field {
+					return ConvertNameToFormat(chk.Name)
+				}
+			}
+			for _, rel := range stmt.Schema.Relationships.Relations {
+				if constraint := rel.ParseConstraint(); constraint != nil && constraint.Field == field {
+					return ConvertNameToFormat(constraint.Name)
+				}
+			}
+		}
+	}
+	return ConvertNameToFormat(name)
+}
+
+func (m Migrator) CreateConstraint(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		for _, chk := range stmt.Schema.ParseCheckConstraints() {
+			if chk.Name == name || chk.Field.Name == name {
+				checkSQL, values, err := m.checkConstraintOf(chk)
+				if err != nil {
+					return err
+				}
+				return m.DB.Exec("ALTER TABLE ? ADD "+checkSQL, append([]interface{}{clause.Table{Name: stmt.Table}}, values...)...).Error
+			}
+		}
+
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == stmt.Schema &&
+				(constraint.Name == name || constraint.Field.Name == name) {
+				foreignKeySQL, values, err := m.foreignKeyOf(constraint)
+				if err != nil {
+					return err
+				}
+				if err := m.DB.Exec("ALTER TABLE ? ADD "+foreignKeySQL, append([]interface{}{clause.Table{Name: stmt.Table}}, values...)...).Error; err != nil {
+					return err
+				}
+				if m.onUpdateCascadeOf(constraint) {
+					return m.createOnUpdateCascade(stmt, constraint)
+				}
+				return nil
+			}
+		}
+
+		return fmt.Errorf("failed to create constraint with name %v", name)
+	})
+}
+
+// DropConstraint drops any kind of constraint. Dropping a primary or unique key referenced by foreign keys fails with
+// ORA-02273, see DropConstraintCascade
+func (m Migrator) DropConstraint(value interface{}, name string) error {
+	return m.dropConstraint(value, name, false)
+}
+
+// DropConstraintCascade drops any kind of constraint, primary and unique keys take the foreign keys referencing them along
+func (m Migrator) DropConstraintCascade(value interface{}, name string) error {
+	return m.dropConstraint(value, name, true)
+}
+
+func (m Migrator) dropConstraint(value interface{}, name string, cascade bool) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		constraint, err := m.GetConstraint(value, name)
+		if err != nil || constraint == nil {
+			return err
+		}
+
+		dropSQL := "ALTER TABLE ? DROP CONSTRAINT ?"
+		if cascade && (constraint.Type == ConstraintPrimaryKey || constraint.Type == ConstraintUnique) {
+			dropSQL += " CASCADE"
+		}
+		if err := m.DB.Exec(dropSQL, clause.Table{Name: stmt.Table}, clause.Column{Name: constraint.Name}).Error; err != nil {
+			return err
+		}
+
+		if constraint.Type == ConstraintForeignKey && m.Dialector.(Dialector).EmulateOnUpdateCascade {
+			return m.dropOnUpdateCascade(constraint.Name)
+		}
+		return nil
+	})
+}
+
+func (m Migrator) HasConstraint(value interface{}, name string) bool {
+	constraint, err := m.GetConstraint(value, name)
+	return err == nil && constraint != nil
+}
+
+// GetConstraint reads the type and state of a constraint from USER_CONSTRAINTS, it's nil when not found
+func (m Migrator) GetConstraint(value interface{}, name string) (constraint *ConstraintInfo, err error) {
+	err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		constraints, err := m.getConstraints(stmt, m.constraintNameOf(stmt, name))
+		if len(constraints) > 0 {
+			constraint = &constraints[0]
+		}
+		return err
+	})
+	return
+}
+
+func (m Migrator) GetConstraints(value interface{}) (constraints []ConstraintInfo, err error) {
+	err = m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
+		constraints, err = m.getConstraints(stmt, "")
+		return
+	})
+	return
+}
+
+func (m Migrator) getConstraints(stmt *gorm.Statement, name string) (constraints []ConstraintInfo, err error) {
+	querySQL := "SELECT CONSTRAINT_NAME, CONSTRAINT_TYPE, STATUS, VALIDATED, DEFERRABLE, DEFERRED FROM USER_CONSTRAINTS " +
+		"WHERE TABLE_NAME = ?"
+	values := []interface{}{ConvertNameToFormat(stmt.Table)}
+	if name != "" {
+		querySQL += " AND CONSTRAINT_NAME = ?"
+		values = append(values, name)
+	}
+
+	rows, err := m.DB.Raw(querySQL+" ORDER BY CONSTRAINT_NAME", values...).Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			constraint                              ConstraintInfo
+			status, validated, deferrable, deferred sql.NullString
+		)
+		if err = rows.Scan(&constraint.Name, &constraint.Type, &status, &validated, &deferrable, &deferred); err != nil {
+			return
+		}
+		constraint.Enabled = status.String == "ENABLED"
+		constraint.Validated = validated.String == "VALIDATED"
+		constraint.Deferrable = deferrable.String == "DEFERRABLE"
+		constraint.Deferred = deferred.String == "DEFERRED"
+		constraints = append(constraints, constraint)
+	}
+	return constraints, rows.Err()
+}
+
+// EnableConstraint enables a constraint, validate checks the existing rows as well
+func (m Migrator) EnableConstraint(value interface{}, name string, validate bool) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		enableSQL := "ALTER TABLE ? MODIFY CONSTRAINT ? ENABLE NOVALIDATE"
+		if validate {
+			enableSQL = "ALTER TABLE ? MODIFY CONSTRAINT ? ENABLE VALIDATE"
+		}
+		return m.DB.Exec(enableSQL, clause.Table{Name: stmt.Table}, clause.Column{Name: m.constraintNameOf(stmt, name)}).Error
+	})
+}
+
+func (m Migrator) DisableConstraint(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec(
+			"ALTER TABLE ? MODIFY CONSTRAINT ? DISABLE", clause.Table{Name: stmt.Table}, clause.Column{Name: m.constraintNameOf(stmt, name)},
+		).Error
+	})
+}
+
+// migrateConstraintStates brings the enabled and validated state of existing checks and foreign keys in line with their tags,
+// deferrability can only be set when a constraint is created
+func (m Migrator) migrateConstraintStates(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		states := map[string]ConstraintState{}
+		for _, chk := range stmt.Schema.ParseCheckConstraints() {
+			state, err := constraintStateOf(chk.Field)
+			if err != nil {
+				return err
+			}
+			states[ConvertNameToFormat(chk.Name)] = state
+		}
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == stmt.Schema {
+				state, err := m.foreignKeyStateOf(constraint)
+				if err != nil {
+					return err
+				}
+				states[ConvertNameToFormat(constraint.Name)] = state
+			}
+		}
+		if len(states) == 0 {
+			return nil
+		}
+
+		constraints, err := m.getConstraints(stmt, "")
+		if err != nil {
+			return err
+		}
+
+		for _, constraint := range constraints {
+			state, ok := states[constraint.Name]
+			if !ok {
+				continue
+			}
+
+			if state.Deferrable != constraint.Deferrable {
+				m.DB.Logger.Warn(stmt.Context, "constraint %s of table %s can't change deferrability, recreate it instead", constraint.Name, stmt.Table)
+			} else if state.Deferrable && state.InitiallyDeferred != constraint.Deferred {
+				initially := "INITIALLY IMMEDIATE"
+				if state.InitiallyDeferred {
+					initially = "INITIALLY DEFERRED"
+				}
+				if err := m.DB.Exec(
+					"ALTER TABLE ? MODIFY CONSTRAINT ? "+initially, clause.Table{Name: stmt.Table}, clause.Column{Name: constraint.Name},
+				).Error; err != nil {
+					return err
+				}
+			}
+
+			if state.Disabled && constraint.Enabled {
+				if err := m.DisableConstraint(value, constraint.Name); err != nil {
+					return err
+				}
+			} else if !state.Disabled && (!constraint.Enabled || constraint.Validated == state.NoValidate) {
+				if err := m.EnableConstraint(value, constraint.Name, !state.NoValidate); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// WithForeignKeysDisabled disables the enabled foreign keys on and referencing the tables of values, runs fc and enables them again,
+// e.g. to bulk load data in any order. As ALTER TABLE commits, fc shouldn't expect to share a transaction with the caller
+func (m Migrator) WithForeignKeysDisabled(fc func(tx *gorm.DB) error, values ...interface{}) (err error) {
+	var tables []string
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			tables = append(tables, ConvertNameToFormat(stmt.Table))
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	type foreignKey struct{ Table, Name string }
+	var foreignKeys []foreignKey
+
+	rows, err := m.DB.Raw(
+		"SELECT c.TABLE_NAME, c.CONSTRAINT_NAME FROM USER_CONSTRAINTS c "+
+			"LEFT JOIN USER_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME "+
+			"WHERE c.CONSTRAINT_TYPE = 'R' AND c.STATUS = 'ENABLED' AND (c.TABLE_NAME IN ? OR r.TABLE_NAME IN ?) "+
+			"ORDER BY c.TABLE_NAME, c.CONSTRAINT_NAME",
+		tables, tables,
+	).Rows()
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var fk foreignKey
+		if err = rows.Scan(&fk.Table, &fk.Name); err != nil {
+			rows.Close()
+			return err
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	var disabled []foreignKey
+	defer func() {
+		for _, fk := range disabled {
+			if enableErr := m.DB.Exec(
+				"ALTER TABLE ? MODIFY CONSTRAINT ? ENABLE", clause.Table{Name: fk.Table}, clause.Column{Name: fk.Name},
+			).Error; enableErr != nil && err == nil {
+				err = enableErr
+			}
+		}
+	}()
+
+	for _, fk := range foreignKeys {
+		if err = m.DB.Exec(
+			"ALTER TABLE ? MODIFY CONSTRAINT ? DISABLE", clause.Table{Name: fk.Table}, clause.Column{Name: fk.Name},
+		).Error; err != nil {
+			return err
+		}
+		disabled = append(disabled, fk)
+	}
+
+	return fc(m.DB.Session(&gorm.Session{}))
+}
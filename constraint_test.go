@@ -0,0 +1,54 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+type constrainedRow struct {
+	ID   uint
+	Code string `gorm:"size:10"`
+}
+
+func TestDropConstraintCascade(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		kind       string
+		cascade    bool
+		want       string
+	}{
+		{"primary key", "PK_CONSTRAINED_ROWS", ConstraintPrimaryKey, false, "ALTER TABLE CONSTRAINED_ROWS DROP CONSTRAINT PK_CONSTRAINED_ROWS"},
+		{"unique", "UQ_CODE", ConstraintUnique, false, "ALTER TABLE CONSTRAINED_ROWS DROP CONSTRAINT UQ_CODE"},
+		{"cascade primary key", "PK_CONSTRAINED_ROWS", ConstraintPrimaryKey, true, "ALTER TABLE CONSTRAINED_ROWS DROP CONSTRAINT PK_CONSTRAINED_ROWS CASCADE"},
+		{"cascade unique", "UQ_CODE", ConstraintUnique, true, "ALTER TABLE CONSTRAINED_ROWS DROP CONSTRAINT UQ_CODE CASCADE"},
+		{"cascade check", "CHK_CODE", ConstraintCheck, true, "ALTER TABLE CONSTRAINED_ROWS DROP CONSTRAINT CHK_CODE"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+				if hasPrefixFold(query, "SELECT CONSTRAINT_NAME, CONSTRAINT_TYPE") {
+					return fakeResult{
+						Columns: []string{"CONSTRAINT_NAME", "CONSTRAINT_TYPE", "STATUS", "VALIDATED", "DEFERRABLE", "DEFERRED"},
+						Rows:    [][]driver.Value{{test.constraint, test.kind, "ENABLED", "VALIDATED", "NOT DEFERRABLE", "IMMEDIATE"}},
+					}
+				}
+				return fakeResult{}
+			})
+
+			m := db.Migrator().(Migrator)
+			drop := m.DropConstraint
+			if test.cascade {
+				drop = m.DropConstraintCascade
+			}
+			if err := drop(&constrainedRow{}, test.constraint); err != nil {
+				t.Fatalf("failed to drop constraint: %v", err)
+			}
+
+			if sql := fake.SQL(); len(sql) == 0 || sql[len(sql)-1] != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+		})
+	}
+}
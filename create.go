@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"reflect"
+	"strings"
 
 	"github.com/thoas/go-funk"
 	"gorm.io/gorm"
@@ -23,7 +24,8 @@ func Create(db *gorm.DB) {
 		return
 	}
 
-	hasDefaultValues := len(schema.FieldsWithDefaultDBValue) > 0
+	returningFields := schema.FieldsWithDefaultDBValue
+	hasDefaultValues := len(returningFields) > 0
 
 	if !stmt.Unscoped {
 		for _, c := range schema.CreateClauses {
@@ -35,9 +37,9 @@ func Create(db *gorm.DB) {
 		values := callbacks.ConvertToCreateValues(stmt)
 		onConflict, hasConflict := stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict)
 		// are all columns in value the primary fields in schema only?
-		if hasConflict && funk.Contains(
+		if hasConflict && len(schema.PrimaryFields) > 0 && funk.Every(
 			funk.Map(values.Columns, func(c clause.Column) string { return c.Name }),
-			funk.Map(schema.PrimaryFields, func(field *gormSchema.Field) string { return field.DBName }),
+			funk.Map(schema.PrimaryFields, func(field *gormSchema.Field) interface{} { return field.DBName }).([]interface{})...,
 		) {
 			stmt.AddClauseIfNotExists(clauses.Merge{
 				Using: []clause.Interface{
@@ -56,7 +58,7 @@ func Create(db *gorm.DB) {
 						}).([]clause.Column),
 					},
 					clause.From{
-						Tables: []clause.Table{{Name: db.Dialector.(Dialector).DummyTableName()}},
+						Tables: []clause.Table{{Name: db.Dialector.(interface{ DummyTableName() string }).DummyTableName()}},
 					},
 				},
 				On: funk.Map(schema.PrimaryFields, func(field *gormSchema.Field) clause.Expression {
@@ -66,16 +68,30 @@ func Create(db *gorm.DB) {
 					}
 				}).([]clause.Expression),
 			})
-			stmt.AddClauseIfNotExists(clauses.WhenMatched{Set: onConflict.DoUpdates})
+			if len(onConflict.DoUpdates) > 0 {
+				stmt.AddClauseIfNotExists(clauses.WhenMatched{Set: onConflict.DoUpdates})
+			}
 			stmt.AddClauseIfNotExists(clauses.WhenNotMatched{Values: values})
 
 			stmt.Build("MERGE", "WHEN MATCHED", "WHEN NOT MATCHED")
 		} else {
+			var (
+				insertValues   clause.Values
+				sequenceFields []*gormSchema.Field
+			)
+			values, insertValues, sequenceFields = withSequenceValues(stmt, values)
+			for _, field := range sequenceFields {
+				if !funk.Contains(returningFields, field) {
+					returningFields = append(returningFields, field)
+				}
+			}
+			hasDefaultValues = len(returningFields) > 0
+
 			stmt.AddClauseIfNotExists(clause.Insert{Table: clause.Table{Name: stmt.Table}})
-			stmt.AddClause(clause.Values{Columns: values.Columns, Values: [][]interface{}{values.Values[0]}})
+			stmt.AddClause(insertValues)
 			if hasDefaultValues {
 				stmt.AddClauseIfNotExists(clause.Returning{
-					Columns: funk.Map(schema.FieldsWithDefaultDBValue, func(field *gormSchema.Field) clause.Column {
+					Columns: funk.Map(returningFields, func(field *gormSchema.Field) clause.Column {
 						return clause.Column{Name: field.DBName}
 					}).([]clause.Column),
 				})
@@ -83,7 +99,7 @@ func Create(db *gorm.DB) {
 			stmt.Build("INSERT", "VALUES", "RETURNING")
 			if hasDefaultValues {
 				stmt.WriteString(" INTO ")
-				for idx, field := range schema.FieldsWithDefaultDBValue {
+				for idx, field := range returningFields {
 					if idx > 0 {
 						stmt.WriteByte(',')
 					}
@@ -104,6 +120,12 @@ func Create(db *gorm.DB) {
 						} else {
 							val = 0
 						}
+					case LOB:
+						// bind CLOB columns as CLOB even if the LOB was created by NewBLOB or Write
+						if field := schema.LookUpField(values.Columns[idx].Name); field != nil && strings.EqualFold(string(field.DataType), "CLOB") {
+							v.IsClob = true
+							val = v
+						}
 					}
 
 					stmt.Vars[idx] = val
@@ -128,7 +150,7 @@ func Create(db *gorm.DB) {
 					if hasDefaultValues {
 						// bind returning value back to reflected value in the respective fields
 						funk.ForEach(
-							funk.Filter(schema.FieldsWithDefaultDBValue, func(field *gormSchema.Field) bool {
+							funk.Filter(returningFields, func(field *gormSchema.Field) bool {
 								return funk.Contains(boundVars, field.Name)
 							}),
 							func(field *gormSchema.Field) {
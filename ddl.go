@@ -0,0 +1,160 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+const ddlOptionKey = "oracle:ddl_options"
+
+// DDLOption controls how the Migrator runs DDL on busy tables, set it with Migrator.WithDDL
+type DDLOption struct {
+	// LockTimeout is the DDL_LOCK_TIMEOUT in seconds DDL waits for the locks of other transactions
+	LockTimeout int
+	// Online creates and drops indexes and moves tables ONLINE
+	Online bool
+	// Retries is how often DDL failing with ORA-00054 (resource busy) or ORA-04021 (lock timeout) is retried
+	Retries int
+	// Backoff is the wait before the first retry, doubled for every further one, one second when zero
+	Backoff time.Duration
+}
+
+func (option DDLOption) validate() error {
+	if option.LockTimeout < 0 || option.LockTimeout > 1000000 {
+		return fmt.Errorf("invalid DDL lock timeout %d, expect 0 to 1000000 seconds", option.LockTimeout)
+	}
+	if option.Retries < 0 || option.Backoff < 0 {
+		return fmt.Errorf("invalid DDL retries %d or backoff %s", option.Retries, option.Backoff)
+	}
+	return nil
+}
+
+// WithDDL returns a Migrator that runs its DDL with option, logging every attempt with its timing
+func (m Migrator) WithDDL(option DDLOption) Migrator {
+	m = m.withSetting(ddlOptionKey, option)
+	if m.DB.Statement.Context == nil {
+		m.DB.Statement.Context = context.Background()
+	}
+
+	connPool := m.DB.Statement.ConnPool
+	if pool, ok := connPool.(ddlConnPool); ok {
+		connPool = pool.ConnPool
+	}
+	m.DB.Statement.ConnPool = ddlConnPool{ConnPool: connPool, option: option, logger: m.DB.Logger}
+	return m
+}
+
+func (m Migrator) ddlOption() (option DDLOption, err error) {
+	if value, ok := m.DB.Get(ddlOptionKey); ok {
+		if option, ok = value.(DDLOption); !ok {
+			return option, fmt.Errorf("invalid %s setting %T", ddlOptionKey, value)
+		}
+	}
+	return option, option.validate()
+}
+
+// MoveTable moves the table of value to another tablespace, or rebuilds it in place when tablespace is empty
+func (m Migrator) MoveTable(value interface{}, tablespace string) error {
+	option, err := m.ddlOption()
+	if err != nil {
+		return err
+	}
+
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		moveSQL := "ALTER TABLE ? MOVE"
+		if option.Online {
+			moveSQL += " ONLINE"
+		}
+		if tablespace != "" {
+			moveSQL += " TABLESPACE " + tablespace
+		}
+		if !option.Online {
+			// moving a table offline leaves its indexes unusable
+			moveSQL += " UPDATE INDEXES"
+		}
+		return m.DB.Exec(moveSQL, clause.Table{Name: stmt.Table}).Error
+	})
+}
+
+// ddlConnPool runs statements under the DDL lock timeout and retries them while the table is busy
+type ddlConnPool struct {
+	gorm.ConnPool
+	option DDLOption
+	logger logger.Interface
+}
+
+func (pool ddlConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (result sql.Result, err error) {
+	backoff := pool.option.Backoff
+	if backoff == 0 {
+		backoff = time.Second
+	}
+
+	for attempt := 1; ; attempt++ {
+		begin := time.Now()
+		result, err = pool.exec(ctx, query, args...)
+		if err == nil {
+			pool.logger.Info(ctx, "DDL attempt %d done in %s: %s", attempt, time.Since(begin), query)
+			return
+		}
+
+		pool.logger.Warn(ctx, "DDL attempt %d failed in %s: %s: %v", attempt, time.Since(begin), query, err)
+		if attempt > pool.option.Retries || !isResourceBusy(err) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// exec sets DDL_LOCK_TIMEOUT on the session running the statement, so a connection is held for both when pooled
+func (pool ddlConnPool) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if pool.option.LockTimeout == 0 {
+		return pool.ConnPool.ExecContext(ctx, query, args...)
+	}
+
+	var session gorm.ConnPool = pool.ConnPool
+	if db, ok := pool.ConnPool.(*sql.DB); ok {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		session = conn
+	}
+
+	if _, err := session.ExecContext(ctx, fmt.Sprintf("ALTER SESSION SET DDL_LOCK_TIMEOUT = %d", pool.option.LockTimeout)); err != nil {
+		return nil, err
+	}
+	defer session.ExecContext(ctx, "ALTER SESSION SET DDL_LOCK_TIMEOUT = 0")
+
+	return session.ExecContext(ctx, query, args...)
+}
+
+func errorCodeOf(err error) int {
+	var oraErr interface{ Code() int }
+	if errors.As(err, &oraErr) {
+		return oraErr.Code()
+	}
+	return 0
+}
+
+func isResourceBusy(err error) bool {
+	code := errorCodeOf(err)
+	return code == 54 || code == 4021
+}
+
+func isTableNotFound(err error) bool {
+	return errorCodeOf(err) == 942
+}
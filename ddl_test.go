@@ -0,0 +1,48 @@
+package oracle
+
+import (
+	"testing"
+)
+
+func TestWithDDLWrapsConnPoolOnce(t *testing.T) {
+	db, _ := openFakeDB(t, Config{}, nil)
+
+	m := db.Migrator().(Migrator).WithDDL(DDLOption{Online: true}).WithDDL(DDLOption{LockTimeout: 10})
+	pool, ok := m.DB.Statement.ConnPool.(ddlConnPool)
+	if !ok {
+		t.Fatalf("expected DDL to run through ddlConnPool, got %T", m.DB.Statement.ConnPool)
+	}
+	if _, nested := pool.ConnPool.(ddlConnPool); nested || pool.option.LockTimeout != 10 {
+		t.Errorf("expected a single ddlConnPool with the latest option, got %+v", pool)
+	}
+}
+
+type movedRow struct {
+	ID uint
+}
+
+func TestMoveTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		option     DDLOption
+		tablespace string
+		want       string
+	}{
+		{"online", DDLOption{Online: true}, "ARCHIVE", "ALTER TABLE MOVED_ROWS MOVE ONLINE TABLESPACE ARCHIVE"},
+		{"online in place", DDLOption{Online: true}, "", "ALTER TABLE MOVED_ROWS MOVE ONLINE"},
+		{"offline", DDLOption{}, "ARCHIVE", "ALTER TABLE MOVED_ROWS MOVE TABLESPACE ARCHIVE UPDATE INDEXES"},
+		{"offline in place", DDLOption{}, "", "ALTER TABLE MOVED_ROWS MOVE UPDATE INDEXES"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, fake := openFakeDB(t, Config{}, nil)
+			if err := db.Migrator().(Migrator).WithDDL(test.option).MoveTable(&movedRow{}, test.tablespace); err != nil {
+				t.Fatalf("failed to move table: %v", err)
+			}
+			if sql := fake.SQL(); len(sql) == 0 || sql[len(sql)-1] != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+		})
+	}
+}
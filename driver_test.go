@@ -0,0 +1,185 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeStatement is a statement run against fakeDriver
+type fakeStatement struct {
+	SQL     string
+	Args    []interface{}
+	Options int
+}
+
+// fakeResult answers a query of fakeDriver
+type fakeResult struct {
+	Columns []string
+	Rows    [][]driver.Value
+	// Err fails the query
+	Err error
+}
+
+// fakeDriver records the statements it runs and answers queries through Query, so the SQL the dialect
+// sends can be checked without a database
+type fakeDriver struct {
+	Query func(query string, args []interface{}) fakeResult
+	// Exec fails the statements it returns an error for
+	Exec func(query string) error
+
+	mu         sync.Mutex
+	statements []fakeStatement
+	openConns  int
+}
+
+func openFakeDB(t *testing.T, config Config, query func(query string, args []interface{}) fakeResult) (*gorm.DB, *fakeDriver) {
+	t.Helper()
+
+	fake := &fakeDriver{Query: query}
+	config.Conn = sql.OpenDB(fake)
+
+	db, err := gorm.Open(New(config), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	fake.Reset()
+	return db, fake
+}
+
+func (fake *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.openConns++
+	return &fakeConn{driver: fake}, nil
+}
+
+func (fake *fakeDriver) Driver() driver.Driver { return fake }
+
+func (fake *fakeDriver) Open(string) (driver.Conn, error) { return fake.Connect(context.Background()) }
+
+func (fake *fakeDriver) Reset() {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.statements = nil
+}
+
+func (fake *fakeDriver) Statements() []fakeStatement {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return append([]fakeStatement{}, fake.statements...)
+}
+
+func (fake *fakeDriver) SQL() (statements []string) {
+	for _, statement := range fake.Statements() {
+		statements = append(statements, statement.SQL)
+	}
+	return
+}
+
+func (fake *fakeDriver) OpenConns() int {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return fake.openConns
+}
+
+func (fake *fakeDriver) record(query string, args []driver.NamedValue, options int) []interface{} {
+	values := make([]interface{}, len(args))
+	for idx, arg := range args {
+		values[idx] = arg.Value
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.statements = append(fake.statements, fakeStatement{SQL: query, Args: values, Options: options})
+	return values
+}
+
+type fakeConn struct {
+	driver  *fakeDriver
+	options int
+}
+
+func (conn *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+
+func (conn *fakeConn) Close() error {
+	conn.driver.mu.Lock()
+	defer conn.driver.mu.Unlock()
+	conn.driver.openConns--
+	return nil
+}
+
+func (conn *fakeConn) Begin() (driver.Tx, error) { return conn, nil }
+
+func (conn *fakeConn) Commit() error { return nil }
+
+func (conn *fakeConn) Rollback() error { return nil }
+
+// CheckNamedValue drops godror options like godror does, counting them for the statement
+func (conn *fakeConn) CheckNamedValue(value *driver.NamedValue) error {
+	if _, ok := value.Value.(godror.Option); ok {
+		conn.options++
+		return driver.ErrRemoveArgument
+	}
+	return nil
+}
+
+func (conn *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	conn.driver.record(query, args, conn.options)
+	conn.options = 0
+	if conn.driver.Exec != nil {
+		if err := conn.driver.Exec(query); err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (conn *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := conn.driver.record(query, args, conn.options)
+	conn.options = 0
+
+	var result fakeResult
+	if conn.driver.Query != nil {
+		result = conn.driver.Query(query, values)
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return &fakeRows{result: result}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (rows *fakeRows) Columns() []string { return rows.result.Columns }
+
+func (rows *fakeRows) Close() error { return nil }
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.next >= len(rows.result.Rows) {
+		return io.EOF
+	}
+	copy(dest, rows.result.Rows[rows.next])
+	rows.next++
+	return nil
+}
+
+// countResult answers COUNT(*) queries
+func countResult(count int64) fakeResult {
+	return fakeResult{Columns: []string{"COUNT(*)"}, Rows: [][]driver.Value{{count}}}
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
@@ -0,0 +1,106 @@
+package oracle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const dropTableOptionKey = "oracle:drop_table_options"
+
+// DropTableOption controls how DropTable drops tables, set it with Migrator.WithDropTable
+type DropTableOption struct {
+	// Purge drops tables without keeping them in the recycle bin, they can't be restored with FlashbackTable then
+	Purge bool
+	// IfExists uses DROP TABLE IF EXISTS on 23ai and later. DropTable ignores ORA-00942 (table or view does not exist)
+	// anyway, so older servers, which don't know the syntax, drop tables without it
+	IfExists bool
+}
+
+// WithDropTable returns a Migrator whose DropTable drops tables with option
+func (m Migrator) WithDropTable(option DropTableOption) Migrator {
+	return m.withSetting(dropTableOptionKey, option)
+}
+
+func (m Migrator) dropTableOption() (option DropTableOption, err error) {
+	if value, ok := m.DB.Get(dropTableOptionKey); ok {
+		if option, ok = value.(DropTableOption); !ok {
+			return option, fmt.Errorf("invalid %s setting %T", dropTableOptionKey, value)
+		}
+	}
+	return
+}
+
+func (option DropTableOption) build(stmt *gorm.Statement, ifExists bool) string {
+	dropSQL := "DROP TABLE "
+	if ifExists {
+		dropSQL += "IF EXISTS "
+	}
+	dropSQL += "?"
+
+	// private temporary tables neither take constraints nor go to the recycle bin
+	if isPrivateTemporaryTable(stmt.Table) {
+		return dropSQL
+	}
+
+	dropSQL += " CASCADE CONSTRAINTS"
+	if option.Purge {
+		dropSQL += " PURGE"
+	}
+	return dropSQL
+}
+
+// serverMajorVersion reads the major version of the server, 0 if it can't be read
+func (m Migrator) serverMajorVersion() int {
+	var version string
+	if err := m.DB.Raw(
+		"SELECT VERSION FROM PRODUCT_COMPONENT_VERSION WHERE PRODUCT LIKE 'Oracle%' AND ROWNUM = 1",
+	).Row().Scan(&version); err != nil {
+		return 0
+	}
+
+	major, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	return major
+}
+
+// FlashbackTable restores the dropped table of value from the recycle bin, renamed to newName unless it's empty.
+// Its indexes and constraints come back with their recycle bin names, and foreign keys referencing it aren't restored
+func (m Migrator) FlashbackTable(value interface{}, newName string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if newName != "" {
+			return m.DB.Exec("FLASHBACK TABLE ? TO BEFORE DROP RENAME TO ?", clause.Table{Name: stmt.Table}, clause.Table{Name: newName}).Error
+		}
+		return m.DB.Exec("FLASHBACK TABLE ? TO BEFORE DROP", clause.Table{Name: stmt.Table}).Error
+	})
+}
+
+// TruncateTableOption is the option of Migrator.TruncateTable
+type TruncateTableOption struct {
+	// ReuseStorage keeps the extents of the table allocated
+	ReuseStorage bool
+	// Cascade truncates the tables referencing it with ON DELETE CASCADE foreign keys too
+	Cascade bool
+}
+
+// TruncateTable deletes all rows of the tables of values, it commits and can't be rolled back
+func (m Migrator) TruncateTable(option TruncateTableOption, values ...interface{}) error {
+	truncateSQL := "TRUNCATE TABLE ?"
+	if option.ReuseStorage {
+		truncateSQL += " REUSE STORAGE"
+	}
+	if option.Cascade {
+		truncateSQL += " CASCADE"
+	}
+
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			return m.DB.Exec(truncateSQL, clause.Table{Name: stmt.Table}).Error
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
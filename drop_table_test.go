@@ -0,0 +1,48 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+type droppedRow struct {
+	ID uint
+}
+
+func TestDropTableIfExists(t *testing.T) {
+	tests := []struct {
+		name    string
+		version fakeResult
+		option  DropTableOption
+		want    string
+	}{
+		{"23ai", versionResult("23.0.0.0.0"), DropTableOption{IfExists: true}, "DROP TABLE IF EXISTS DROPPED_ROWS CASCADE CONSTRAINTS"},
+		{"19c", versionResult("19.0.0.0.0"), DropTableOption{IfExists: true}, "DROP TABLE DROPPED_ROWS CASCADE CONSTRAINTS"},
+		{"unknown version", fakeResult{}, DropTableOption{IfExists: true, Purge: true}, "DROP TABLE DROPPED_ROWS CASCADE CONSTRAINTS PURGE"},
+		{"without IfExists", versionResult("23.0.0.0.0"), DropTableOption{}, "DROP TABLE DROPPED_ROWS CASCADE CONSTRAINTS"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+				if hasPrefixFold(query, "SELECT VERSION FROM PRODUCT_COMPONENT_VERSION") {
+					return test.version
+				}
+				return fakeResult{}
+			})
+
+			if err := db.Migrator().(Migrator).WithDropTable(test.option).DropTable(&droppedRow{}); err != nil {
+				t.Fatalf("failed to drop table: %v", err)
+			}
+
+			sql := fake.SQL()
+			if len(sql) == 0 || sql[len(sql)-1] != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+		})
+	}
+}
+
+func versionResult(version string) fakeResult {
+	return fakeResult{Columns: []string{"VERSION"}, Rows: [][]driver.Value{{version}}}
+}
@@ -0,0 +1,123 @@
+package oracle
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// onDeleteOf keeps the ON DELETE actions Oracle supports, NO ACTION and RESTRICT are what it does without one
+func (m Migrator) onDeleteOf(constraint *schema.Constraint) string {
+	switch onDelete := strings.Join(strings.Fields(strings.ToUpper(constraint.OnDelete)), " "); onDelete {
+	case "CASCADE", "SET NULL":
+		return onDelete
+	case "", "NO ACTION", "RESTRICT":
+	default:
+		m.DB.Logger.Warn(m.DB.Statement.Context, "ON DELETE %s of constraint %s isn't supported by Oracle, it's ignored", constraint.OnDelete, constraint.Name)
+	}
+	return ""
+}
+
+// onUpdateOf reports whether ON UPDATE CASCADE of the constraint is emulated with a trigger, see Config.EmulateOnUpdateCascade,
+// Oracle has no ON UPDATE actions, so the others are ignored
+func (m Migrator) onUpdateOf(constraint *schema.Constraint) (emulated bool) {
+	switch onUpdate := strings.Join(strings.Fields(strings.ToUpper(constraint.OnUpdate)), " "); onUpdate {
+	case "", "NO ACTION", "RESTRICT":
+	case "CASCADE":
+		if m.onUpdateCascadeOf(constraint) {
+			return true
+		}
+		fallthrough
+	default:
+		m.DB.Logger.Warn(m.DB.Statement.Context, "ON UPDATE %s of constraint %s isn't supported by Oracle, it's ignored", constraint.OnUpdate, constraint.Name)
+	}
+	return false
+}
+
+func (m Migrator) onUpdateCascadeOf(constraint *schema.Constraint) bool {
+	return m.Dialector.(Dialector).EmulateOnUpdateCascade && strings.EqualFold(strings.TrimSpace(constraint.OnUpdate), "CASCADE")
+}
+
+// createOnUpdateCascade creates a compound trigger on the referenced table, which carries key updates over to the referencing rows
+// once the statement is done. The foreign key is deferred, so it's only checked when both sides agree again
+func (m Migrator) createOnUpdateCascade(stmt *gorm.Statement, constraint *schema.Constraint) error {
+	var (
+		parent      = stmt.Quote(constraint.ReferenceSchema.Table)
+		child       = stmt.Quote(constraint.Schema.Table)
+		fields      []string
+		updateOf    []string
+		changed     []string
+		collect     []string
+		assignments []string
+		conditions  []string
+	)
+
+	for i, reference := range constraint.References {
+		column, foreignKey := stmt.Quote(reference.DBName), stmt.Quote(constraint.ForeignKeys[i].DBName)
+		fields = append(fields, fmt.Sprintf("OLD_%d %s.%s%%TYPE, NEW_%d %s.%s%%TYPE", i, parent, column, i, parent, column))
+		updateOf = append(updateOf, column)
+		changed = append(changed, fmt.Sprintf(
+			"(:OLD.%[1]s <> :NEW.%[1]s OR (:OLD.%[1]s IS NULL AND :NEW.%[1]s IS NOT NULL) OR (:OLD.%[1]s IS NOT NULL AND :NEW.%[1]s IS NULL))", column,
+		))
+		collect = append(collect, fmt.Sprintf("K.OLD_%d := :OLD.%s; K.NEW_%d := :NEW.%s;", i, column, i, column))
+		assignments = append(assignments, fmt.Sprintf("%s = G_KEYS(I).NEW_%d", foreignKey, i))
+		conditions = append(conditions, fmt.Sprintf("%s = G_KEYS(I).OLD_%d", foreignKey, i))
+	}
+
+	return m.DB.Exec(fmt.Sprintf(
+		"CREATE OR REPLACE TRIGGER %s FOR UPDATE OF %s ON %s COMPOUND TRIGGER "+
+			"TYPE KEY_T IS RECORD (%s); TYPE KEYS_T IS TABLE OF KEY_T INDEX BY PLS_INTEGER; G_KEYS KEYS_T; "+
+			"AFTER EACH ROW IS BEGIN IF %s THEN DECLARE K KEY_T; BEGIN %s G_KEYS(G_KEYS.COUNT + 1) := K; END; END IF; END AFTER EACH ROW; "+
+			"AFTER STATEMENT IS BEGIN FOR I IN 1 .. G_KEYS.COUNT LOOP UPDATE %s SET %s WHERE %s; END LOOP; G_KEYS.DELETE; END AFTER STATEMENT; "+
+			"END;",
+		onUpdateCascadeTriggerName(constraint.Name), strings.Join(updateOf, ", "), parent,
+		strings.Join(fields, ", "),
+		strings.Join(changed, " OR "), strings.Join(collect, " "),
+		child, strings.Join(assignments, ", "), strings.Join(conditions, " AND "),
+	)).Error
+}
+
+// dropOnUpdateCascade drops the ON UPDATE CASCADE triggers of foreign keys, if any. A trigger lives on the referenced
+// table, so it outlives the foreign key and would fail every update of the referenced table with ORA-04098 then
+func (m Migrator) dropOnUpdateCascade(constraintNames ...string) error {
+	if len(constraintNames) == 0 {
+		return nil
+	}
+
+	triggerNames := make([]string, 0, len(constraintNames))
+	for _, name := range constraintNames {
+		triggerNames = append(triggerNames, onUpdateCascadeTriggerName(name))
+	}
+
+	var existing []string
+	rows, err := m.DB.Raw("SELECT TRIGGER_NAME FROM USER_TRIGGERS WHERE TRIGGER_NAME IN ?", triggerNames).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		existing = append(existing, name)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, name := range existing {
+		if err := m.DB.Exec("DROP TRIGGER ?", clause.Table{Name: name}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func onUpdateCascadeTriggerName(constraintName string) string {
+	return limitIdentifier("TRG_" + ConvertNameToFormat(constraintName) + "_UPD")
+}
@@ -0,0 +1,148 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+type cascadedParent struct {
+	ID uint
+}
+
+type cascadedChild struct {
+	ID       uint
+	ParentID uint
+	Parent   cascadedParent `gorm:"constraint:OnUpdate:CASCADE"`
+}
+
+const cascadedForeignKey = "FK_CASCADED_CHILDREN_PARENT"
+
+// cascadedTrigger is TRG_FK_CASCADED_CHILDREN_PARENT_UPD cut to 30 bytes
+var cascadedTrigger = "TRG_FK_CASCADED_CHILD_" + hashOf("TRG_FK_CASCADED_CHILDREN_PARENT_UPD")
+
+func cascadedQuery(query string, args []interface{}) fakeResult {
+	switch {
+	case hasPrefixFold(query, "SELECT TRIGGER_NAME FROM USER_TRIGGERS"):
+		return fakeResult{Columns: []string{"TRIGGER_NAME"}, Rows: [][]driver.Value{{cascadedTrigger}}}
+	case hasPrefixFold(query, "SELECT CONSTRAINT_NAME, CONSTRAINT_TYPE"):
+		return fakeResult{
+			Columns: []string{"CONSTRAINT_NAME", "CONSTRAINT_TYPE", "STATUS", "VALIDATED", "DEFERRABLE", "DEFERRED"},
+			Rows:    [][]driver.Value{{cascadedForeignKey, ConstraintForeignKey, "ENABLED", "VALIDATED", "DEFERRABLE", "DEFERRED"}},
+		}
+	}
+	return fakeResult{}
+}
+
+func TestDropTableDropsOnUpdateCascadeTrigger(t *testing.T) {
+	db, fake := openFakeDB(t, Config{EmulateOnUpdateCascade: true}, cascadedQuery)
+
+	if err := db.Migrator().DropTable(&cascadedChild{}); err != nil {
+		t.Fatalf("failed to drop table: %v", err)
+	}
+
+	if sql := fake.SQL(); len(sql) == 0 || sql[len(sql)-1] != "DROP TRIGGER "+cascadedTrigger {
+		t.Errorf("expected the trigger on the parent table to be dropped, got %q", sql)
+	}
+}
+
+// oraError is an error carrying an ORA- code, like godror's
+type oraError int
+
+func (code oraError) Error() string { return fmt.Sprintf("ORA-%05d", int(code)) }
+
+func (code oraError) Code() int { return int(code) }
+
+func TestDropTableSkipsOnUpdateCascadeTriggers(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		err    error
+	}{
+		{"not emulated", Config{}, nil},
+		{"missing table", Config{EmulateOnUpdateCascade: true}, oraError(942)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, fake := openFakeDB(t, test.config, cascadedQuery)
+			fake.Exec = func(query string) error {
+				if hasPrefixFold(query, "DROP TABLE") {
+					return test.err
+				}
+				return nil
+			}
+
+			if err := db.Migrator().DropTable(&cascadedChild{}); err != nil {
+				t.Fatalf("failed to drop table: %v", err)
+			}
+			if sql := fake.SQL(); len(sql) != 1 {
+				t.Errorf("expected no trigger lookup, got %q", sql)
+			}
+		})
+	}
+}
+
+func TestOnUpdateCascadeTriggerNameLength(t *testing.T) {
+	if name := onUpdateCascadeTriggerName(cascadedForeignKey); name != cascadedTrigger || len(name) > maxIdentifierLength {
+		t.Errorf("onUpdateCascadeTriggerName() = %q, want %q of at most %d bytes", name, cascadedTrigger, maxIdentifierLength)
+	}
+	if name := onUpdateCascadeTriggerName("FK_ORDERS_USER"); name != "TRG_FK_ORDERS_USER_UPD" {
+		t.Errorf("expected short names to be kept, got %q", name)
+	}
+}
+
+func TestDropConstraintDropsOnUpdateCascadeTrigger(t *testing.T) {
+	db, fake := openFakeDB(t, Config{EmulateOnUpdateCascade: true}, cascadedQuery)
+
+	if err := db.Migrator().DropConstraint(&cascadedChild{}, "Parent"); err != nil {
+		t.Fatalf("failed to drop constraint: %v", err)
+	}
+
+	var dropped []string
+	for _, sql := range fake.SQL() {
+		if !hasPrefixFold(sql, "SELECT") {
+			dropped = append(dropped, sql)
+		}
+	}
+	if want := []string{"ALTER TABLE CASCADED_CHILDREN DROP CONSTRAINT " + cascadedForeignKey, "DROP TRIGGER " + cascadedTrigger}; fmt.Sprint(dropped) != fmt.Sprint(want) {
+		t.Errorf("got %q, want %q", dropped, want)
+	}
+}
+
+func TestMigrateConstraintStatesOfOnUpdateCascade(t *testing.T) {
+	db, fake := openFakeDB(t, Config{EmulateOnUpdateCascade: true}, cascadedQuery)
+	warnings := &warningLogger{Interface: logger.Discard}
+	db.Logger = warnings
+
+	if err := db.Migrator().(Migrator).migrateConstraintStates(&cascadedChild{}); err != nil {
+		t.Fatalf("failed to migrate constraint states: %v", err)
+	}
+
+	if len(warnings.messages) != 0 {
+		t.Errorf("expected the emulated foreign key to be deferred as created, got warnings %q", warnings.messages)
+	}
+	for _, sql := range fake.SQL() {
+		if !hasPrefixFold(sql, "SELECT") {
+			t.Errorf("expected no DDL, got %q", sql)
+		}
+	}
+}
+
+// warningLogger records the warnings
+type warningLogger struct {
+	logger.Interface
+	messages []string
+}
+
+func (l *warningLogger) LogMode(logger.LogLevel) logger.Interface { return l }
+
+func (l *warningLogger) Warn(_ context.Context, msg string, data ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(msg, data...))
+}
+
+func (l *warningLogger) Trace(context.Context, time.Time, func() (string, int64), error) {}
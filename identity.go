@@ -0,0 +1,142 @@
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils"
+)
+
+const (
+	IdentityAlways          = "ALWAYS"
+	IdentityByDefault       = "BY DEFAULT"
+	IdentityByDefaultOnNull = "BY DEFAULT ON NULL"
+)
+
+type identityOption struct {
+	Generation string
+	SequenceOption
+}
+
+// identityOf parses identity columns from `autoIncrement` and the `identity` tag,
+// e.g. `gorm:"identity:always,start:100,increment:10,cache:50"`
+func identityOf(field *schema.Field) (option identityOption, ok bool) {
+	if _, hasSequence := field.TagSettings["SEQUENCE"]; hasSequence {
+		return
+	}
+
+	setting, hasIdentity := field.TagSettings["IDENTITY"]
+	if val, hasAutoIncrement := field.TagSettings["AUTOINCREMENT"]; !hasIdentity && !(hasAutoIncrement && utils.CheckTruth(val)) {
+		return
+	}
+
+	option.Generation = IdentityByDefault
+	for _, entry := range strings.Split(setting, ",") {
+		kv := strings.SplitN(entry, ":", 2)
+		key := strings.Join(strings.Fields(strings.ToUpper(kv[0])), " ")
+		var value int64
+		if len(kv) > 1 {
+			value, _ = strconv.ParseInt(strings.TrimSpace(kv[1]), 10, 64)
+		}
+
+		switch key {
+		case IdentityAlways, IdentityByDefault, IdentityByDefaultOnNull:
+			option.Generation = key
+		case "DEFAULT":
+			option.Generation = IdentityByDefault
+		case "ON NULL", "DEFAULT ON NULL":
+			option.Generation = IdentityByDefaultOnNull
+		case "START", "START WITH":
+			option.StartWith = value
+		case "INCREMENT", "INCREMENT BY":
+			option.IncrementBy = value
+		case "CACHE":
+			option.Cache = value
+		case "NOCACHE":
+			option.NoCache = true
+		}
+	}
+	return option, true
+}
+
+func (option identityOption) build(alter bool) string {
+	sql := " GENERATED " + option.Generation + " AS IDENTITY"
+	// restarting an identity that already handed out values would hand them out again
+	if alter {
+		option.StartWith = 0
+	}
+	if options := option.SequenceOption.build(false); options != "" {
+		sql += " (" + strings.TrimSpace(options) + ")"
+	}
+	return sql
+}
+
+var identityOptionPattern = regexp.MustCompile(`(INCREMENT BY|CACHE_SIZE): (\d+)`)
+
+// identityColumnOf reads the current identity settings from USER_TAB_IDENTITY_COLS, ok is false for other columns
+func (m Migrator) identityColumnOf(stmt *gorm.Statement, column string) (option identityOption, ok bool, err error) {
+	var generationType, identityOptions, defaultOnNull string
+	if err = m.DB.Raw(
+		"SELECT i.GENERATION_TYPE, i.IDENTITY_OPTIONS, c.DEFAULT_ON_NULL FROM USER_TAB_IDENTITY_COLS i "+
+			"JOIN USER_TAB_COLUMNS c ON c.TABLE_NAME = i.TABLE_NAME AND c.COLUMN_NAME = i.COLUMN_NAME "+
+			"WHERE i.TABLE_NAME = ? AND i.COLUMN_NAME = ?",
+		ConvertNameToFormat(stmt.Table), ConvertNameToFormat(column),
+	).Row().Scan(&generationType, &identityOptions, &defaultOnNull); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+		}
+		return
+	}
+
+	option.Generation = generationType
+	if defaultOnNull == "YES" {
+		option.Generation = IdentityByDefaultOnNull
+	}
+
+	for _, matches := range identityOptionPattern.FindAllStringSubmatch(identityOptions, -1) {
+		value, _ := strconv.ParseInt(matches[2], 10, 64)
+		switch matches[1] {
+		case "INCREMENT BY":
+			option.IncrementBy = value
+		case "CACHE_SIZE":
+			option.Cache = value
+		}
+	}
+	return option, true, nil
+}
+
+// alterIdentity syncs identity settings, it reports whether the column is still an identity column
+// so that AlterColumn leaves its data type alone
+func (m Migrator) alterIdentity(stmt *gorm.Statement, field *schema.Field) (bool, error) {
+	current, isIdentity, err := m.identityColumnOf(stmt, field.DBName)
+	if err != nil {
+		return false, err
+	}
+	wanted, wantsIdentity := identityOf(field)
+
+	switch {
+	case isIdentity && wantsIdentity:
+		if wanted.Generation == current.Generation &&
+			(wanted.IncrementBy == 0 || wanted.IncrementBy == current.IncrementBy) &&
+			(wanted.Cache == 0 || wanted.Cache == current.Cache) &&
+			(!wanted.NoCache || current.Cache == 0) {
+			return true, nil
+		}
+		return true, m.DB.Exec(
+			"ALTER TABLE ? MODIFY ?"+wanted.build(true), clause.Table{Name: stmt.Table}, clause.Column{Name: field.DBName},
+		).Error
+	case isIdentity:
+		// the identity may just not be declared in the model, dropping it would stop the column from generating values
+		m.DB.Logger.Warn(m.DB.Statement.Context, "column %s.%s is an identity column without an identity tag, it's left as is", stmt.Table, field.DBName)
+		return true, nil
+	case wantsIdentity:
+		m.DB.Logger.Warn(m.DB.Statement.Context, "column %s.%s can't be turned into an identity column, recreate it instead", stmt.Table, field.DBName)
+	}
+	return false, nil
+}
@@ -0,0 +1,140 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+type identityRow struct {
+	ID      uint `gorm:"primaryKey;identity:always,start:100,increment:10,cache:50"`
+	Counter int  `gorm:"autoIncrement"`
+	OnNull  int  `gorm:"identity:on null,nocache"`
+	Number  int
+	Ordered int `gorm:"identity;sequence"`
+}
+
+func TestIdentityOf(t *testing.T) {
+	parsed, err := schema.Parse(&identityRow{}, &sync.Map{}, Namer{})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		field string
+		ok    bool
+		want  string
+	}{
+		{"ID", true, " GENERATED ALWAYS AS IDENTITY (START WITH 100 INCREMENT BY 10 CACHE 50)"},
+		{"Counter", true, " GENERATED BY DEFAULT AS IDENTITY"},
+		{"OnNull", true, " GENERATED BY DEFAULT ON NULL AS IDENTITY (NOCACHE)"},
+		{"Number", false, ""},
+		{"Ordered", false, ""},
+	}
+
+	for _, test := range tests {
+		option, ok := identityOf(parsed.LookUpField(test.field))
+		if ok != test.ok {
+			t.Errorf("identityOf(%s) reports %v, want %v", test.field, ok, test.ok)
+			continue
+		}
+		if got := option.build(false); ok && got != test.want {
+			t.Errorf("identityOf(%s).build() = %q, want %q", test.field, got, test.want)
+		}
+	}
+}
+
+func identityColumnResult(generation, options, defaultOnNull string) fakeResult {
+	return fakeResult{
+		Columns: []string{"GENERATION_TYPE", "IDENTITY_OPTIONS", "DEFAULT_ON_NULL"},
+		Rows:    [][]driver.Value{{generation, options, defaultOnNull}},
+	}
+}
+
+func TestAlterIdentity(t *testing.T) {
+	tableNotFound := errors.New("ORA-00942: table or view does not exist")
+
+	tests := []struct {
+		name     string
+		field    string
+		current  fakeResult
+		want     []string
+		warnings int
+		err      error
+	}{
+		{
+			name:    "unchanged",
+			field:   "ID",
+			current: identityColumnResult("ALWAYS", "START WITH: 100, INCREMENT BY: 10, CACHE_SIZE: 50", "NO"),
+		},
+		{
+			name:    "changed",
+			field:   "ID",
+			current: identityColumnResult("BY DEFAULT", "START WITH: 100, INCREMENT BY: 1, CACHE_SIZE: 20", "NO"),
+			want:    []string{"ALTER TABLE IDENTITY_ROWS MODIFY ID GENERATED ALWAYS AS IDENTITY (INCREMENT BY 10 CACHE 50)"},
+		},
+		{
+			name:     "undeclared identity is kept",
+			field:    "Number",
+			current:  identityColumnResult("BY DEFAULT", "INCREMENT BY: 1", "NO"),
+			warnings: 1,
+		},
+		{
+			name:     "identity can't be added",
+			field:    "Counter",
+			warnings: 1,
+			want:     []string{"ALTER TABLE IDENTITY_ROWS MODIFY COUNTER INTEGER"},
+		},
+		{
+			name:    "query errors are returned",
+			field:   "Number",
+			current: fakeResult{Err: tableNotFound},
+			err:     tableNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+				switch {
+				case hasPrefixFold(query, "SELECT COUNT(*)"):
+					return countResult(1)
+				case hasPrefixFold(query, "SELECT i.GENERATION_TYPE"):
+					if args[0] != "IDENTITY_ROWS" {
+						t.Errorf("expected the identity to be looked up by the upper case table name, got %v", args)
+					}
+					return test.current
+				case hasPrefixFold(query, "SELECT DATA_TYPE"):
+					return fakeResult{
+						Columns: []string{"DATA_TYPE", "CHAR_LENGTH", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE"},
+						Rows:    [][]driver.Value{{"NUMBER", int64(0), int64(22), int64(10), int64(0)}},
+					}
+				}
+				return fakeResult{}
+			})
+			warnings := &warningLogger{Interface: logger.Discard}
+			db.Logger = warnings
+
+			if err := db.Migrator().AlterColumn(&identityRow{}, test.field); !errors.Is(err, test.err) {
+				t.Fatalf("AlterColumn() = %v, want %v", err, test.err)
+			}
+
+			var ddl []string
+			for _, sql := range fake.SQL() {
+				if !hasPrefixFold(sql, "SELECT") {
+					ddl = append(ddl, sql)
+				}
+			}
+			if len(ddl) != len(test.want) || (len(ddl) > 0 && ddl[0] != test.want[0]) {
+				t.Errorf("got DDL %q, want %q", ddl, test.want)
+			}
+			if len(warnings.messages) != test.warnings {
+				t.Errorf("got warnings %q, want %d", warnings.messages, test.warnings)
+			}
+		})
+	}
+}
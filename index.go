@@ -0,0 +1,313 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// indexOption holds the Oracle specific settings of an index, declared on any of its fields, e.g.
+//
+//	Email string `gorm:"index:idx_email,expression:UPPER(email),invisible,online"`
+//	Code  string `gorm:"index:idx_code,class:BITMAP,type:LOCAL,compress:1,tablespace:IDX"`
+type indexOption struct {
+	// Class is UNIQUE or BITMAP
+	Class string
+	// Locality is LOCAL or GLOBAL for indexes on partitioned tables
+	Locality       string
+	Reverse        bool
+	Invisible      bool
+	Online         bool
+	Compress       bool
+	CompressPrefix int
+	Tablespace     string
+}
+
+type indexColumn struct {
+	Expression string
+	Descending bool
+}
+
+type indexDefinition struct {
+	Unique         bool
+	Bitmap         bool
+	Reverse        bool
+	Invisible      bool
+	Compress       bool
+	CompressPrefix int
+	Locality       string
+	Tablespace     string
+	Columns        []indexColumn
+}
+
+func (m Migrator) indexOptionOf(stmt *gorm.Statement, idx *schema.Index) (option indexOption, err error) {
+	option.Class = strings.ToUpper(idx.Class)
+	switch option.Class {
+	case "", "UNIQUE", "BITMAP":
+	default:
+		return option, fmt.Errorf("invalid class %q of index %s, expect UNIQUE or BITMAP", idx.Class, idx.Name)
+	}
+
+	// type is taken by GORM for index methods of other databases, so it's only used for the locality
+	switch locality := strings.ToUpper(idx.Type); locality {
+	case "LOCAL", "GLOBAL":
+		option.Locality = locality
+	}
+
+	for _, opt := range idx.Fields {
+		settings := m.indexSettingsOf(stmt, opt.Field)[idx.Name]
+		if _, ok := settings["REVERSE"]; ok {
+			option.Reverse = true
+		}
+		if _, ok := settings["INVISIBLE"]; ok {
+			option.Invisible = true
+		}
+		if _, ok := settings["ONLINE"]; ok {
+			option.Online = true
+		}
+		if compress, ok := settings["COMPRESS"]; ok {
+			option.Compress = true
+			if compress != "COMPRESS" {
+				if option.CompressPrefix, err = strconv.Atoi(compress); err != nil || option.CompressPrefix < 1 {
+					return option, fmt.Errorf("invalid compress %q of index %s, expect a positive prefix length", compress, idx.Name)
+				}
+			}
+		}
+		if tablespace := settings["TABLESPACE"]; tablespace != "" {
+			option.Tablespace = tablespace
+		}
+	}
+
+	if option.Class == "BITMAP" && option.Reverse {
+		return option, fmt.Errorf("bitmap index %s can't be a reverse key index", idx.Name)
+	}
+	return option, nil
+}
+
+// indexSettingsOf parses the index tags of field by index name, the same way GORM names them
+func (m Migrator) indexSettingsOf(stmt *gorm.Statement, field *schema.Field) map[string]map[string]string {
+	results := map[string]map[string]string{}
+	for _, value := range strings.Split(field.Tag.Get("gorm"), ";") {
+		v := strings.Split(value, ":")
+		if k := strings.TrimSpace(strings.ToUpper(v[0])); k != "INDEX" && k != "UNIQUEINDEX" {
+			continue
+		}
+
+		tag := strings.Join(v[1:], ":")
+		name := tag
+		if idx := strings.Index(tag, ","); idx != -1 {
+			name = tag[0:idx]
+		}
+		if name == "" {
+			name = m.DB.NamingStrategy.IndexName(stmt.Table, field.Name)
+		}
+		results[name] = schema.ParseTagSetting(tag, ",")
+	}
+	return results
+}
+
+func (option indexOption) build(storage StorageOption) (createIndexSQL string) {
+	createIndexSQL = "CREATE "
+	if option.Class != "" {
+		createIndexSQL += option.Class + " "
+	}
+	createIndexSQL += "INDEX ? ON ??"
+
+	if option.Locality != "" {
+		createIndexSQL += " " + option.Locality
+	}
+	if option.Reverse {
+		createIndexSQL += " REVERSE"
+	}
+
+	if option.Tablespace != "" {
+		createIndexSQL += " TABLESPACE " + option.Tablespace
+	} else if storage.IndexTablespace != "" {
+		createIndexSQL += " TABLESPACE " + storage.IndexTablespace
+	}
+
+	if option.CompressPrefix > 0 {
+		createIndexSQL += fmt.Sprintf(" COMPRESS %d", option.CompressPrefix)
+	} else if option.Compress {
+		createIndexSQL += " COMPRESS"
+	}
+
+	if storage.Logging != "" {
+		createIndexSQL += " " + strings.ToUpper(storage.Logging)
+	}
+	if option.Invisible {
+		createIndexSQL += " INVISIBLE"
+	}
+	if option.Online {
+		createIndexSQL += " ONLINE"
+	}
+	return
+}
+
+// definitionOf is the index definition GORM expects from the model
+func (option indexOption) definitionOf(idx *schema.Index) indexDefinition {
+	definition := indexDefinition{
+		Unique:         option.Class == "UNIQUE",
+		Bitmap:         option.Class == "BITMAP",
+		Reverse:        option.Reverse,
+		Invisible:      option.Invisible,
+		Compress:       option.Compress,
+		CompressPrefix: option.CompressPrefix,
+		Locality:       option.Locality,
+		Tablespace:     option.Tablespace,
+	}
+
+	for _, opt := range idx.Fields {
+		column := indexColumn{Expression: opt.Expression, Descending: strings.EqualFold(opt.Sort, "DESC")}
+		if column.Expression == "" {
+			column.Expression = opt.DBName
+		}
+		definition.Columns = append(definition.Columns, column)
+	}
+	return definition
+}
+
+// indexDefinitionOf reads the definition of an index from USER_INDEXES, USER_IND_COLUMNS and USER_IND_EXPRESSIONS
+func (m Migrator) indexDefinitionOf(stmt *gorm.Statement, name string) (definition indexDefinition, found bool, err error) {
+	var (
+		indexType, uniqueness, compression, visibility string
+		prefixLength                                   sql.NullInt64
+		tablespace, locality                           sql.NullString
+	)
+
+	row := m.DB.Raw(
+		"SELECT i.INDEX_TYPE, i.UNIQUENESS, i.COMPRESSION, i.PREFIX_LENGTH, i.VISIBILITY, i.TABLESPACE_NAME, p.LOCALITY "+
+			"FROM USER_INDEXES i LEFT JOIN USER_PART_INDEXES p ON p.INDEX_NAME = i.INDEX_NAME "+
+			"WHERE i.TABLE_NAME = ? AND i.INDEX_NAME = ?",
+		ConvertNameToFormat(stmt.Table), ConvertNameToFormat(name),
+	).Row()
+	if err = row.Scan(&indexType, &uniqueness, &compression, &prefixLength, &visibility, &tablespace, &locality); err != nil {
+		if err == sql.ErrNoRows {
+			err = nil
+		}
+		return
+	}
+
+	definition = indexDefinition{
+		Unique:         uniqueness == "UNIQUE",
+		Bitmap:         strings.Contains(indexType, "BITMAP"),
+		Reverse:        strings.HasSuffix(indexType, "/REV"),
+		Invisible:      visibility == "INVISIBLE",
+		Compress:       strings.HasPrefix(compression, "ENABLED"),
+		CompressPrefix: int(prefixLength.Int64),
+		Locality:       locality.String,
+		Tablespace:     tablespace.String,
+	}
+
+	rows, err := m.DB.Raw(
+		"SELECT c.COLUMN_NAME, c.DESCEND, e.COLUMN_EXPRESSION FROM USER_IND_COLUMNS c "+
+			"LEFT JOIN USER_IND_EXPRESSIONS e ON e.INDEX_NAME = c.INDEX_NAME AND e.COLUMN_POSITION = c.COLUMN_POSITION "+
+			"WHERE c.INDEX_NAME = ? ORDER BY c.COLUMN_POSITION",
+		ConvertNameToFormat(name),
+	).Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			columnName, descend string
+			expression          sql.NullString
+		)
+		if err = rows.Scan(&columnName, &descend, &expression); err != nil {
+			return
+		}
+
+		column := indexColumn{Expression: columnName, Descending: descend == "DESC"}
+		if expression.Valid {
+			column.Expression = expression.String
+		}
+		definition.Columns = append(definition.Columns, column)
+	}
+	return definition, true, rows.Err()
+}
+
+// changedFrom reports whether the expected definition differs from the existing one,
+// the tablespace and prefix length are only compared when they were declared
+func (definition indexDefinition) changedFrom(existing indexDefinition) bool {
+	if definition.Unique != existing.Unique || definition.Bitmap != existing.Bitmap || definition.Reverse != existing.Reverse ||
+		definition.Invisible != existing.Invisible || definition.Compress != existing.Compress ||
+		len(definition.Columns) != len(existing.Columns) {
+		return true
+	}
+
+	if definition.CompressPrefix > 0 && definition.CompressPrefix != existing.CompressPrefix {
+		return true
+	}
+	if definition.Locality == "LOCAL" && existing.Locality != "LOCAL" {
+		return true
+	}
+	if definition.Tablespace != "" && !strings.EqualFold(definition.Tablespace, existing.Tablespace) {
+		return true
+	}
+
+	for i, column := range definition.Columns {
+		if column.Descending != existing.Columns[i].Descending ||
+			normalizeIndexExpression(column.Expression) != normalizeIndexExpression(existing.Columns[i].Expression) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeIndexExpression drops the quotes and spaces Oracle adds when it stores an expression
+func normalizeIndexExpression(expression string) string {
+	return strings.NewReplacer(`"`, "", " ", "").Replace(strings.ToUpper(expression))
+}
+
+// indexNameOf resolves a field name or index name of the model to the stored index name, exactly once
+func (m Migrator) indexNameOf(stmt *gorm.Statement, name string) (owner, index string) {
+	if idx := stmt.Schema.LookIndex(name); idx != nil {
+		name = idx.Name
+	}
+	return splitOwner(ConvertNameToFormat(name))
+}
+
+func (m Migrator) indexChanged(stmt *gorm.Statement, idx *schema.Index) (bool, error) {
+	option, err := m.indexOptionOf(stmt, idx)
+	if err != nil {
+		return false, err
+	}
+
+	existing, found, err := m.indexDefinitionOf(stmt, idx.Name)
+	if err != nil || !found {
+		return false, err
+	}
+	return option.definitionOf(idx).changedFrom(existing), nil
+}
+
+// migrateIndexes recreates the indexes whose definition changed since they were created
+func (m Migrator) migrateIndexes(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if option, ok := temporaryTableOf(stmt); ok && option.Private {
+			return nil
+		}
+
+		for _, idx := range stmt.Schema.ParseIndexes() {
+			changed, err := m.indexChanged(stmt, &idx)
+			if err != nil {
+				return err
+			}
+
+			if changed {
+				if err := m.DB.Migrator().DropIndex(value, idx.Name); err != nil {
+					return err
+				}
+				if err := m.DB.Migrator().CreateIndex(value, idx.Name); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,106 @@
+package oracle
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type indexedUser struct {
+	ID    uint
+	Name  string `gorm:"index"`
+	Email string `gorm:"uniqueIndex:idx_user_email"`
+	Code  string `gorm:"index:hr.idx_user_code"`
+}
+
+var (
+	createIndexPattern = regexp.MustCompile(`^CREATE (?:UNIQUE |BITMAP )?INDEX (\S+) ON ([^\s(]+)`)
+	dropIndexPattern   = regexp.MustCompile(`^DROP INDEX (\S+)`)
+)
+
+// indexCatalog answers USER_INDEXES and ALL_INDEXES from the indexes created and dropped through fake,
+// the current user is APP
+func indexCatalog(fake **fakeDriver) func(query string, args []interface{}) fakeResult {
+	return func(query string, args []interface{}) fakeResult {
+		indexes := map[[3]string]bool{}
+		for _, statement := range (*fake).SQL() {
+			if matches := createIndexPattern.FindStringSubmatch(statement); matches != nil {
+				owner, index := splitOwner(strings.ToUpper(matches[1]))
+				if owner == "" {
+					owner = "APP"
+				}
+				indexes[[3]string{owner, index, strings.ToUpper(matches[2])}] = true
+			} else if matches := dropIndexPattern.FindStringSubmatch(statement); matches != nil {
+				owner, index := splitOwner(strings.ToUpper(matches[1]))
+				if owner == "" {
+					owner = "APP"
+				}
+				for key := range indexes {
+					if key[0] == owner && key[1] == index {
+						delete(indexes, key)
+					}
+				}
+			}
+		}
+
+		switch {
+		case hasPrefixFold(query, "SELECT COUNT(*) FROM ALL_INDEXES WHERE OWNER = :1 AND INDEX_NAME = :2 AND TABLE_NAME = :3"):
+			if indexes[[3]string{args[0].(string), args[1].(string), args[2].(string)}] {
+				return countResult(1)
+			}
+		case hasPrefixFold(query, "SELECT COUNT(*) FROM USER_INDEXES WHERE INDEX_NAME = :1 AND TABLE_NAME = :2"):
+			if indexes[[3]string{"APP", args[0].(string), args[1].(string)}] {
+				return countResult(1)
+			}
+		}
+		return countResult(0)
+	}
+}
+
+func TestHasIndexFindsCreatedIndexes(t *testing.T) {
+	var fake *fakeDriver
+	db, fake := openFakeDB(t, Config{}, indexCatalog(&fake))
+	m := db.Migrator()
+
+	tests := []struct {
+		create string
+		lookup []string
+		absent []string
+	}{
+		{create: "Name", lookup: []string{"Name", "IDX_INDEXED_USERS_NAME", "idx_indexed_users_name"}},
+		{create: "idx_user_email", lookup: []string{"Email", "idx_user_email", "IDX_USER_EMAIL"}},
+		{create: "Code", lookup: []string{"Code", "hr.idx_user_code", "HR.IDX_USER_CODE"}, absent: []string{"idx_user_code"}},
+	}
+
+	for _, test := range tests {
+		for _, name := range append(test.lookup, test.absent...) {
+			if m.HasIndex(&indexedUser{}, name) {
+				t.Errorf("HasIndex(%q) before CreateIndex(%q) = true", name, test.create)
+			}
+		}
+
+		if err := m.CreateIndex(&indexedUser{}, test.create); err != nil {
+			t.Fatalf("failed to create index %q: %v", test.create, err)
+		}
+
+		for _, name := range test.lookup {
+			if !m.HasIndex(&indexedUser{}, name) {
+				t.Errorf("HasIndex(%q) after CreateIndex(%q) = false", name, test.create)
+			}
+		}
+		for _, name := range test.absent {
+			if m.HasIndex(&indexedUser{}, name) {
+				t.Errorf("HasIndex(%q) after CreateIndex(%q) = true", name, test.create)
+			}
+		}
+
+		if err := m.DropIndex(&indexedUser{}, test.lookup[0]); err != nil {
+			t.Fatalf("failed to drop index %q: %v", test.lookup[0], err)
+		}
+		for _, name := range test.lookup {
+			if m.HasIndex(&indexedUser{}, name) {
+				t.Errorf("HasIndex(%q) after DropIndex(%q) = true", name, test.lookup[0])
+			}
+		}
+	}
+}
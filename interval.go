@@ -0,0 +1,123 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	IntervalDayToSecond schema.DataType = "interval"
+	IntervalYearToMonth schema.DataType = "interval_ym"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// IntervalYM maps to INTERVAL YEAR TO MONTH
+type IntervalYM struct {
+	Years, Months int
+}
+
+func (ym IntervalYM) GormDataType() string {
+	return string(IntervalYearToMonth)
+}
+
+func (ym IntervalYM) String() string {
+	total, sign := ym.Years*12+ym.Months, "+"
+	if total < 0 {
+		total, sign = -total, "-"
+	}
+	return fmt.Sprintf("%s%d-%02d", sign, total/12, total%12)
+}
+
+func (ym IntervalYM) Value() (driver.Value, error) {
+	return ym.String(), nil
+}
+
+var intervalYMPattern = regexp.MustCompile(`^\s*([+-]?)(\d+)-(\d+)\s*$`)
+
+func (ym *IntervalYM) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*ym = IntervalYM{}
+		return nil
+	case godror.IntervalYM:
+		*ym = IntervalYM{Years: v.Years, Months: v.Months}
+		return nil
+	case []byte:
+		return ym.Scan(string(v))
+	case string:
+		matches := intervalYMPattern.FindStringSubmatch(v)
+		if matches == nil {
+			return fmt.Errorf("failed to parse %q as INTERVAL YEAR TO MONTH", v)
+		}
+		// the sign leads the whole interval, e.g. -0-05 is minus five months
+		years, _ := strconv.Atoi(matches[2])
+		months, _ := strconv.Atoi(matches[3])
+		if matches[1] == "-" {
+			years, months = -years, -months
+		}
+		*ym = IntervalYM{Years: years, Months: months}
+		return nil
+	}
+	return fmt.Errorf("unsupported type %T for IntervalYM", src)
+}
+
+func isIntervalDayToSecond(d Dialector, field *schema.Field) bool {
+	if strings.EqualFold(string(field.DataType), string(IntervalDayToSecond)) {
+		return true
+	}
+	return d.DurationAsInterval && field.IndirectFieldType == durationType
+}
+
+func intervalDataTypeOf(dataType schema.DataType, field *schema.Field) string {
+	precision, scale := field.Precision, field.Scale
+	if precision == 0 {
+		precision = 9
+	}
+
+	if dataType == IntervalYearToMonth {
+		return fmt.Sprintf("INTERVAL YEAR(%d) TO MONTH", precision)
+	}
+
+	if scale == 0 {
+		scale = 9
+	}
+	return fmt.Sprintf("INTERVAL DAY(%d) TO SECOND(%d)", precision, scale)
+}
+
+// intervalLiteral renders interval values as Oracle literals
+func intervalLiteral(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case time.Duration:
+		sign := "+"
+		if v < 0 {
+			sign, v = "-", -v
+		}
+		days := v / (24 * time.Hour)
+		v -= days * 24 * time.Hour
+		hours := v / time.Hour
+		v -= hours * time.Hour
+		minutes := v / time.Minute
+		v -= minutes * time.Minute
+		seconds := v / time.Second
+		v -= seconds * time.Second
+		return fmt.Sprintf(
+			"INTERVAL '%s%d %02d:%02d:%02d.%09d' DAY(9) TO SECOND(9)", sign, days, hours, minutes, seconds, v,
+		), true
+	case IntervalYM:
+		return fmt.Sprintf("INTERVAL '%s' YEAR(9) TO MONTH", v.String()), true
+	case *IntervalYM:
+		if v != nil {
+			return intervalLiteral(*v)
+		}
+	}
+	return "", false
+}
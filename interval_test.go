@@ -0,0 +1,71 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/godror/godror"
+)
+
+func TestIntervalYMValueAndScan(t *testing.T) {
+	tests := []struct {
+		interval IntervalYM
+		value    string
+	}{
+		{IntervalYM{}, "+0-00"},
+		{IntervalYM{Years: 1, Months: 2}, "+1-02"},
+		{IntervalYM{Months: 5}, "+0-05"},
+		{IntervalYM{Months: -5}, "-0-05"},
+		{IntervalYM{Years: -3}, "-3-00"},
+		{IntervalYM{Years: -3, Months: -11}, "-3-11"},
+		{IntervalYM{Years: 120, Months: 1}, "+120-01"},
+	}
+
+	for _, test := range tests {
+		value, err := test.interval.Value()
+		if err != nil || value != test.value {
+			t.Errorf("%+v.Value() = %v, %v, want %q", test.interval, value, err, test.value)
+		}
+
+		for _, src := range []interface{}{test.value, []byte(test.value)} {
+			var scanned IntervalYM
+			if err := scanned.Scan(src); err != nil || scanned != test.interval {
+				t.Errorf("Scan(%q) = %+v, %v, want %+v", src, scanned, err, test.interval)
+			}
+		}
+	}
+}
+
+func TestIntervalYMNormalizes(t *testing.T) {
+	if got := (IntervalYM{Years: 1, Months: -2}).String(); got != "+0-10" {
+		t.Errorf("String() = %q, want +0-10", got)
+	}
+	if got := (IntervalYM{Months: 14}).String(); got != "+1-02" {
+		t.Errorf("String() = %q, want +1-02", got)
+	}
+}
+
+func TestIntervalYMScan(t *testing.T) {
+	tests := []struct {
+		src  interface{}
+		want IntervalYM
+	}{
+		{nil, IntervalYM{}},
+		{"5-01", IntervalYM{Years: 5, Months: 1}},
+		{" -1-06 ", IntervalYM{Years: -1, Months: -6}},
+		{godror.IntervalYM{Years: -2, Months: -3}, IntervalYM{Years: -2, Months: -3}},
+	}
+
+	for _, test := range tests {
+		scanned := IntervalYM{Years: 9}
+		if err := scanned.Scan(test.src); err != nil || scanned != test.want {
+			t.Errorf("Scan(%v) = %+v, %v, want %+v", test.src, scanned, err, test.want)
+		}
+	}
+
+	for _, src := range []interface{}{"1--2", "P1Y", 12} {
+		var scanned IntervalYM
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("expected Scan(%v) to fail, got %+v", src, scanned)
+		}
+	}
+}
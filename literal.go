@@ -0,0 +1,108 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// literalOf renders v as an Oracle literal, for DDL, which can't take bind variables, and for Explain
+func literalOf(v interface{}) string {
+	if rv := reflect.ValueOf(v); v == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return "NULL"
+	}
+
+	if literal, ok := intervalLiteral(v); ok {
+		return literal
+	}
+
+	switch v := v.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case []byte:
+		return "HEXTORAW('" + strings.ToUpper(hex.EncodeToString(v)) + "')"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return "TIMESTAMP '" + v.Format("2006-01-02 15:04:05.999999999 -07:00") + "'"
+	case LOB, *LOB:
+		return "'<lob>'"
+	case driver.Valuer:
+		if value, err := v.Value(); err == nil {
+			return literalOf(value)
+		}
+	case fmt.Stringer:
+		return literalOf(v.String())
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr:
+		return literalOf(rv.Elem().Interface())
+	case reflect.Bool:
+		return literalOf(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.String:
+		return literalOf(rv.String())
+	}
+	return literalOf(fmt.Sprint(v))
+}
+
+// inlineVars replaces the :1, :2... placeholders of sql with the literals of vars in a single pass,
+// leaving quoted text, quoted identifiers and comments alone
+func inlineVars(sql string, vars ...interface{}) string {
+	var (
+		builder strings.Builder
+		quote   byte
+	)
+	builder.Grow(len(sql))
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote == '*':
+			if c == '*' && i+1 < len(sql) && sql[i+1] == '/' {
+				builder.WriteString("*/")
+				quote, i = 0, i+1
+				continue
+			}
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			quote = '\n'
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			builder.WriteString("/*")
+			quote, i = '*', i+1
+			continue
+		case c == ':':
+			end := i + 1
+			for end < len(sql) && sql[end] >= '0' && sql[end] <= '9' {
+				end++
+			}
+			if idx, err := strconv.Atoi(sql[i+1 : end]); err == nil && idx > 0 && idx <= len(vars) {
+				builder.WriteString(literalOf(vars[idx-1]))
+				i = end - 1
+				continue
+			}
+		}
+		builder.WriteByte(c)
+	}
+	return builder.String()
+}
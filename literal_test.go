@@ -0,0 +1,80 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExplain(t *testing.T) {
+	dialector := Dialector{Config: &Config{}}
+
+	tests := []struct {
+		name string
+		sql  string
+		vars []interface{}
+		want string
+	}{
+		{
+			name: "duration",
+			sql:  "SELECT :1 FROM DUAL",
+			vars: []interface{}{2*time.Hour + 3*time.Minute + 4*time.Second},
+			want: "SELECT INTERVAL '+0 02:03:04.000000000' DAY(9) TO SECOND(9) FROM DUAL",
+		},
+		{
+			name: "negative duration",
+			sql:  "SELECT :1 FROM DUAL",
+			vars: []interface{}{-(26*time.Hour + 500*time.Millisecond)},
+			want: "SELECT INTERVAL '-1 02:00:00.500000000' DAY(9) TO SECOND(9) FROM DUAL",
+		},
+		{
+			name: "year to month",
+			sql:  "SELECT :1, :2 FROM DUAL",
+			vars: []interface{}{IntervalYM{Years: 1, Months: 2}, &IntervalYM{Years: -3}},
+			want: "SELECT INTERVAL '+1-02' YEAR(9) TO MONTH, INTERVAL '-3-00' YEAR(9) TO MONTH FROM DUAL",
+		},
+		{
+			name: "quotes are doubled",
+			sql:  "SELECT * FROM USERS WHERE NAME = :1",
+			vars: []interface{}{"O'Brien"},
+			want: "SELECT * FROM USERS WHERE NAME = 'O''Brien'",
+		},
+		{
+			name: "time",
+			sql:  "SELECT * FROM USERS WHERE CREATED_AT > :1",
+			vars: []interface{}{time.Date(2020, 1, 1, 10, 30, 0, 500, time.FixedZone("", 2*60*60))},
+			want: "SELECT * FROM USERS WHERE CREATED_AT > TIMESTAMP '2020-01-01 10:30:00.0000005 +02:00'",
+		},
+		{
+			name: "quoted text is left alone",
+			sql:  `SELECT TIMESTAMP '2020-01-01 10:30:00', "A:1", :1 /* :1 */ FROM DUAL -- :1`,
+			vars: []interface{}{"x"},
+			want: `SELECT TIMESTAMP '2020-01-01 10:30:00', "A:1", 'x' /* :1 */ FROM DUAL -- :1`,
+		},
+		{
+			name: "literals aren't placeholders again",
+			sql:  "SELECT :1, :2 FROM DUAL",
+			vars: []interface{}{":2", 1},
+			want: "SELECT ':2', 1 FROM DUAL",
+		},
+		{
+			name: "other values",
+			sql:  "INSERT INTO T VALUES (:1, :2, :3, :4, :5, :6, :7)",
+			vars: []interface{}{true, false, nil, 1.5, uint8(7), []byte{0xCA, 0xFE}, (*string)(nil)},
+			want: "INSERT INTO T VALUES (1, 0, NULL, 1.5, 7, HEXTORAW('CAFE'), NULL)",
+		},
+		{
+			name: "unknown placeholders are kept",
+			sql:  "SELECT :1, :2 FROM DUAL",
+			vars: []interface{}{1},
+			want: "SELECT 1, :2 FROM DUAL",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := dialector.Explain(test.sql, test.vars...); got != test.want {
+				t.Errorf("Explain() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
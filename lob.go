@@ -0,0 +1,274 @@
+package oracle
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var lobType = reflect.TypeOf(LOB{})
+
+// LOB streams BLOB/CLOB content instead of holding it in memory.
+//
+// Written content is spooled to a temporary file and copied into a temporary LOB when the
+// row is created or updated. Queries leave LOB columns out and fetch each value by primary key
+// once it's first read, holding a connection until it's read up or closed. Models without
+// primary key, Raw queries and custom selects fetch the content along with the row.
+type LOB struct {
+	IsClob  bool
+	reader  io.Reader
+	spool   *os.File
+	locator *lobLocator
+}
+
+func NewBLOB(r io.Reader) LOB {
+	return LOB{reader: r}
+}
+
+func NewCLOB(r io.Reader) LOB {
+	return LOB{IsClob: true, reader: r}
+}
+
+func (lob LOB) GormDataType() string {
+	if lob.IsClob {
+		return "CLOB"
+	}
+	return "BLOB"
+}
+
+func (lob *LOB) Read(p []byte) (int, error) {
+	if lob.reader == nil {
+		switch {
+		case lob.spool != nil:
+			if _, err := lob.spool.Seek(0, io.SeekStart); err != nil {
+				return 0, err
+			}
+			lob.reader = lob.spool
+		case lob.locator != nil:
+			reader, err := lob.locator.open()
+			if err != nil {
+				return 0, err
+			}
+			lob.reader = reader
+		default:
+			return 0, io.EOF
+		}
+	}
+	return lob.reader.Read(p)
+}
+
+func (lob *LOB) Write(p []byte) (n int, err error) {
+	if lob.spool == nil {
+		lob.closeReader()
+		if lob.spool, err = ioutil.TempFile("", "oracle-lob-"); err != nil {
+			return 0, err
+		}
+		lob.reader, lob.locator = nil, nil
+	}
+	return lob.spool.Write(p)
+}
+
+// Close removes the spool file created by Write and releases the connection of a queried LOB,
+// which is fetched again when read later
+func (lob *LOB) Close() error {
+	err := lob.closeReader()
+	if lob.spool != nil {
+		name := lob.spool.Name()
+		if closeErr := lob.spool.Close(); err == nil {
+			err = closeErr
+		}
+		if removeErr := os.Remove(name); err == nil {
+			err = removeErr
+		}
+	}
+	lob.spool, lob.reader = nil, nil
+	return err
+}
+
+func (lob *LOB) closeReader() error {
+	if reader, ok := lob.reader.(*lobReader); ok {
+		lob.reader = nil
+		return reader.Close()
+	}
+	return nil
+}
+
+func (lob LOB) Value() (driver.Value, error) {
+	reader := lob.reader
+	switch {
+	case lob.spool != nil:
+		if _, err := lob.spool.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		reader = lob.spool
+	case reader == nil && lob.locator != nil:
+		// saving a queried row copies the stored content, the connection is released once it's read up
+		lobReader, err := lob.locator.open()
+		if err != nil {
+			return nil, err
+		}
+		reader = lobReader
+	}
+	if reader == nil {
+		return nil, nil
+	}
+	return godror.Lob{Reader: reader, IsClob: lob.IsClob}, nil
+}
+
+func (lob *LOB) Scan(src interface{}) error {
+	lob.Close()
+	lob.locator = nil
+	switch v := src.(type) {
+	case nil:
+	case *godror.Lob:
+		lob.IsClob, lob.reader = v.IsClob, v.Reader
+	case godror.Lob:
+		lob.IsClob, lob.reader = v.IsClob, v.Reader
+	case []byte:
+		lob.reader = bytes.NewReader(v)
+	case string:
+		lob.IsClob, lob.reader = true, strings.NewReader(v)
+	default:
+		return fmt.Errorf("unsupported type %T for LOB", src)
+	}
+	return nil
+}
+
+// lobLocator selects the LOB of a single row by its primary key
+type lobLocator struct {
+	ctx   context.Context
+	pool  gorm.ConnPool
+	query string
+	vars  []interface{}
+}
+
+// open fetches the LOB as a reader on a connection of its own, the reader keeps it until it's read up or closed
+func (locator *lobLocator) open() (io.Reader, error) {
+	reader := &lobReader{}
+	pool := locator.pool
+	if db, ok := pool.(*sql.DB); ok {
+		conn, err := db.Conn(locator.ctx)
+		if err != nil {
+			return nil, err
+		}
+		reader.conn, pool = conn, conn
+	}
+
+	rows, err := pool.QueryContext(locator.ctx, locator.query, append(locator.vars[:len(locator.vars):len(locator.vars)], godror.LobAsReader())...)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	reader.rows = rows
+
+	var value interface{}
+	if rows.Next() {
+		err = rows.Scan(&value)
+	} else if err = rows.Err(); err == nil {
+		err = fmt.Errorf("failed to fetch LOB, row not found: %s", locator.query)
+	}
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		reader.Reader = strings.NewReader("")
+	case *godror.Lob:
+		reader.Reader = v.Reader
+	case godror.Lob:
+		reader.Reader = v.Reader
+	case []byte:
+		reader.Reader = bytes.NewReader(v)
+	case string:
+		reader.Reader = strings.NewReader(v)
+	default:
+		reader.Close()
+		return nil, fmt.Errorf("unsupported type %T for LOB", value)
+	}
+	return reader, nil
+}
+
+// lobReader holds the rows and connection a LOB is read from, the locator is only valid while they are open
+type lobReader struct {
+	io.Reader
+	rows *sql.Rows
+	conn *sql.Conn
+}
+
+func (reader *lobReader) Read(p []byte) (n int, err error) {
+	if n, err = reader.Reader.Read(p); err == io.EOF {
+		reader.Close()
+	}
+	return
+}
+
+func (reader *lobReader) Close() (err error) {
+	if reader.rows != nil {
+		err = reader.rows.Close()
+		reader.rows = nil
+	}
+	if reader.conn != nil {
+		if closeErr := reader.conn.Close(); err == nil {
+			err = closeErr
+		}
+		reader.conn = nil
+	}
+	return
+}
+
+// setLOBLocators points the LOB fields of the queried rows to their content
+func setLOBLocators(db *gorm.DB, lobFields []*schema.Field) {
+	stmt := db.Statement
+	setLocators := func(row reflect.Value) {
+		var (
+			conditions = make([]string, len(stmt.Schema.PrimaryFields))
+			vars       = make([]interface{}, len(stmt.Schema.PrimaryFields))
+		)
+		for idx, field := range stmt.Schema.PrimaryFields {
+			conditions[idx] = fmt.Sprintf("%s = :%d", stmt.Quote(field.DBName), idx+1)
+			vars[idx], _ = field.ValueOf(row)
+		}
+
+		for _, field := range lobFields {
+			value := field.ReflectValueOf(row)
+			if value.Kind() == reflect.Ptr {
+				value.Set(reflect.New(lobType))
+				value = value.Elem()
+			}
+
+			lob := value.Addr().Interface().(*LOB)
+			lob.Close()
+			*lob = LOB{
+				IsClob: strings.HasSuffix(strings.ToUpper(string(field.DataType)), "CLOB"),
+				locator: &lobLocator{
+					ctx:   stmt.Context,
+					pool:  stmt.ConnPool,
+					query: fmt.Sprintf("SELECT %s FROM %s WHERE %s", stmt.Quote(field.DBName), stmt.Quote(stmt.Table), strings.Join(conditions, " AND ")),
+					vars:  vars,
+				},
+			}
+		}
+	}
+
+	switch value := reflect.Indirect(stmt.ReflectValue); value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			setLocators(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		setLocators(value)
+	}
+}
@@ -0,0 +1,144 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/godror/godror"
+)
+
+type lobDocument struct {
+	ID      uint
+	Title   string `gorm:"size:8000"`
+	Thumb   []byte
+	Content LOB
+	Notes   *LOB `gorm:"type:CLOB"`
+}
+
+func lobDocumentQuery(query string, args []interface{}) fakeResult {
+	switch {
+	case hasPrefixFold(query, "SELECT ID,TITLE,THUMB FROM LOB_DOCUMENTS"):
+		return fakeResult{
+			Columns: []string{"ID", "TITLE", "THUMB"},
+			Rows: [][]driver.Value{
+				{int64(1), strings.Repeat("t", 5000), []byte{1, 2, 3}},
+				{int64(2), "short", []byte{4}},
+			},
+		}
+	case hasPrefixFold(query, "SELECT CONTENT FROM LOB_DOCUMENTS WHERE ID = :1"):
+		return fakeResult{
+			Columns: []string{"CONTENT"},
+			Rows:    [][]driver.Value{{&godror.Lob{Reader: strings.NewReader("content of " + string('0'+rune(args[0].(uint))))}}},
+		}
+	case hasPrefixFold(query, "SELECT NOTES FROM LOB_DOCUMENTS WHERE ID = :1"):
+		return fakeResult{Columns: []string{"NOTES"}, Rows: [][]driver.Value{{nil}}}
+	}
+	return fakeResult{}
+}
+
+func TestQueryFetchesLOBsLazily(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, lobDocumentQuery)
+
+	var documents []lobDocument
+	if err := db.Find(&documents).Error; err != nil {
+		t.Fatalf("failed to find documents: %v", err)
+	}
+
+	statements := fake.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("expected the LOB columns to be fetched on read only, got %q", fake.SQL())
+	}
+	if statements[0].SQL != "SELECT ID,TITLE,THUMB FROM LOB_DOCUMENTS" || statements[0].Options != 0 {
+		t.Errorf("expected a query without LOB columns and godror options, got %+v", statements[0])
+	}
+
+	if len(documents) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(documents))
+	}
+	if len(documents[0].Title) != 5000 || string(documents[0].Thumb) != "\x01\x02\x03" || documents[1].Title != "short" {
+		t.Errorf("failed to scan the other columns, got %+v", documents)
+	}
+
+	if documents[1].Content.IsClob || documents[1].Notes == nil || !documents[1].Notes.IsClob {
+		t.Errorf("expected Content to be a BLOB and Notes a CLOB, got %+v", documents[1])
+	}
+
+	conns := fake.OpenConns()
+	content, err := ioutil.ReadAll(&documents[1].Content)
+	if err != nil || string(content) != "content of 2" {
+		t.Fatalf("failed to read content, got %q, %v", content, err)
+	}
+
+	statements = fake.Statements()
+	if last := statements[len(statements)-1]; last.SQL != "SELECT CONTENT FROM LOB_DOCUMENTS WHERE ID = :1" || last.Options != 1 ||
+		len(last.Args) != 1 || last.Args[0] != uint(2) {
+		t.Errorf("expected the content to be fetched by primary key as a reader, got %+v", last)
+	}
+	if documents[1].Content.reader.(*lobReader).conn != nil {
+		t.Errorf("expected the connection to be released once the content was read up")
+	}
+	if fake.OpenConns() > conns+1 {
+		t.Errorf("expected at most one more connection, got %d, had %d", fake.OpenConns(), conns)
+	}
+
+	notes, err := ioutil.ReadAll(documents[0].Notes)
+	if err != nil || len(notes) != 0 {
+		t.Errorf("expected NULL notes to read empty, got %q, %v", notes, err)
+	}
+}
+
+func TestQueryKeepsLOBsOfCustomSelects(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+		return fakeResult{Columns: []string{"ID", "CONTENT"}, Rows: [][]driver.Value{{int64(1), []byte("inline")}}}
+	})
+
+	var document lobDocument
+	if err := db.Select("ID", "CONTENT").First(&document).Error; err != nil {
+		t.Fatalf("failed to find document: %v", err)
+	}
+
+	if sql := fake.SQL(); len(sql) != 1 || !hasPrefixFold(sql[0], "SELECT ID,CONTENT FROM LOB_DOCUMENTS") {
+		t.Errorf("expected the selected columns only, got %q", sql)
+	}
+
+	if content, err := ioutil.ReadAll(&document.Content); err != nil || string(content) != "inline" {
+		t.Errorf("expected the content fetched with the row, got %q, %v", content, err)
+	}
+}
+
+func TestLOBCloseReleasesConnection(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, lobDocumentQuery)
+
+	var document lobDocument
+	if err := db.Where("ID = ?", 1).Find(&document).Error; err != nil {
+		t.Fatalf("failed to find document: %v", err)
+	}
+
+	conns := fake.OpenConns()
+	buf := make([]byte, 4)
+	if _, err := document.Content.Read(buf); err != nil || string(buf) != "cont" {
+		t.Fatalf("failed to read content, got %q, %v", buf, err)
+	}
+
+	reader := document.Content.reader.(*lobReader)
+	if reader.conn == nil {
+		t.Fatalf("expected a connection to be held while the content is read")
+	}
+
+	if err := document.Content.Close(); err != nil {
+		t.Fatalf("failed to close content: %v", err)
+	}
+	if reader.conn != nil || reader.rows != nil {
+		t.Errorf("expected Close to release rows and connection")
+	}
+	if fake.OpenConns() > conns+1 {
+		t.Errorf("expected at most one more pooled connection, got %d, had %d", fake.OpenConns(), conns)
+	}
+
+	// a closed LOB is fetched again
+	if content, err := ioutil.ReadAll(&document.Content); err != nil || string(content) != "content of 1" {
+		t.Errorf("failed to read content again, got %q, %v", content, err)
+	}
+}
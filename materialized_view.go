@@ -0,0 +1,145 @@
+package oracle
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MaterializedViewOption struct {
+	Query *gorm.DB
+	// Build is IMMEDIATE or DEFERRED
+	Build string
+	// Refresh is FAST, COMPLETE or FORCE
+	Refresh string
+	// RefreshOn is COMMIT or DEMAND
+	RefreshOn          string
+	EnableQueryRewrite bool
+}
+
+type MaterializedViewLogOption struct {
+	WithPrimaryKey     bool
+	WithRowID          bool
+	WithSequence       bool
+	Columns            []string
+	IncludingNewValues bool
+}
+
+func (m Migrator) GetMaterializedViews() (viewList []string, err error) {
+	err = m.DB.Raw("SELECT MVIEW_NAME FROM USER_MVIEWS ORDER BY MVIEW_NAME").Scan(&viewList).Error
+	return
+}
+
+// HasMaterializedView looks in USER_MVIEWS, or ALL_MVIEWS for owner qualified names
+func (m Migrator) HasMaterializedView(name string) bool {
+	var count int64
+	if owner, view := splitOwner(ConvertNameToFormat(name)); owner != "" {
+		m.DB.Raw("SELECT COUNT(*) FROM ALL_MVIEWS WHERE OWNER = ? AND MVIEW_NAME = ?", owner, view).Row().Scan(&count)
+	} else {
+		m.DB.Raw("SELECT COUNT(*) FROM USER_MVIEWS WHERE MVIEW_NAME = ?", view).Row().Scan(&count)
+	}
+	return count > 0
+}
+
+func (m Migrator) CreateMaterializedView(name string, option MaterializedViewOption) error {
+	if option.Query == nil {
+		return fmt.Errorf("failed to create materialized view %s, query is required", name)
+	}
+
+	createSQL := "CREATE MATERIALIZED VIEW ?"
+	if build := strings.ToUpper(option.Build); build != "" {
+		if build != "IMMEDIATE" && build != "DEFERRED" {
+			return fmt.Errorf("invalid materialized view build option %q, expect IMMEDIATE or DEFERRED", option.Build)
+		}
+		createSQL += " BUILD " + build
+	}
+
+	if option.Refresh != "" || option.RefreshOn != "" {
+		createSQL += " REFRESH"
+		if refresh := strings.ToUpper(option.Refresh); refresh != "" {
+			if refresh != "FAST" && refresh != "COMPLETE" && refresh != "FORCE" {
+				return fmt.Errorf("invalid materialized view refresh option %q, expect FAST, COMPLETE or FORCE", option.Refresh)
+			}
+			createSQL += " " + refresh
+		}
+		if refreshOn := strings.ToUpper(option.RefreshOn); refreshOn != "" {
+			if refreshOn != "COMMIT" && refreshOn != "DEMAND" {
+				return fmt.Errorf("invalid materialized view refresh option ON %q, expect COMMIT or DEMAND", option.RefreshOn)
+			}
+			createSQL += " ON " + refreshOn
+		}
+	}
+
+	if option.EnableQueryRewrite {
+		createSQL += " ENABLE QUERY REWRITE"
+	}
+	createSQL += " AS ?"
+
+	// DDL can't take bind variables, so the query is inlined
+	stmt := &gorm.Statement{DB: m.DB}
+	clause.Expr{SQL: createSQL, Vars: []interface{}{clause.Table{Name: name}, option.Query}}.Build(stmt)
+	return m.DB.Exec(inlineVars(stmt.SQL.String(), stmt.Vars...)).Error
+}
+
+func (m Migrator) DropMaterializedView(name string) error {
+	return m.DB.Exec("DROP MATERIALIZED VIEW ?", clause.Table{Name: name}).Error
+}
+
+// RefreshMaterializedView runs DBMS_MVIEW.REFRESH, method is C (complete), F (fast), ? (force) or empty for the view's default
+func (m Migrator) RefreshMaterializedView(name string, method string) error {
+	return m.DB.Exec("BEGIN DBMS_MVIEW.REFRESH(?, ?); END;", ConvertNameToFormat(name), method).Error
+}
+
+func (m Migrator) CreateMaterializedViewLog(value interface{}, option MaterializedViewLogOption) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		var (
+			createSQL = "CREATE MATERIALIZED VIEW LOG ON ?"
+			values    = []interface{}{clause.Table{Name: stmt.Table}}
+			with      []string
+		)
+
+		if option.WithPrimaryKey {
+			with = append(with, "PRIMARY KEY")
+		}
+		if option.WithRowID {
+			with = append(with, "ROWID")
+		}
+		if option.WithSequence {
+			with = append(with, "SEQUENCE")
+		}
+
+		if len(with) > 0 || len(option.Columns) > 0 {
+			createSQL += " WITH " + strings.Join(with, ", ")
+			if len(option.Columns) > 0 {
+				if len(with) > 0 {
+					createSQL += " "
+				}
+				var columns []clause.Column
+				for _, name := range option.Columns {
+					if stmt.Schema != nil {
+						if field := stmt.Schema.LookUpField(name); field != nil {
+							name = field.DBName
+						}
+					}
+					columns = append(columns, clause.Column{Name: name})
+				}
+				createSQL += "?"
+				values = append(values, columns)
+			}
+		}
+
+		if option.IncludingNewValues {
+			createSQL += " INCLUDING NEW VALUES"
+		}
+
+		return m.DB.Exec(createSQL, values...).Error
+	})
+}
+
+func (m Migrator) DropMaterializedViewLog(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec("DROP MATERIALIZED VIEW LOG ON ?", clause.Table{Name: stmt.Table}).Error
+	})
+}
@@ -1,18 +1,28 @@
 package oracle
 
 import (
+	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
 )
 
 type Migrator struct {
 	migrator.Migrator
 }
 
+// withSetting returns a Migrator whose statement carries value under key. The statement is cloned, rather than started
+// anew, so the settings of earlier With... options are kept
+func (m Migrator) withSetting(key string, value interface{}) Migrator {
+	m.DB = m.DB.Session(&gorm.Session{WithConditions: true}).Set(key, value).Session(&gorm.Session{})
+	return m
+}
+
 func (m Migrator) CurrentDatabase() (name string) {
 	m.DB.Raw(
 		fmt.Sprintf(`SELECT ORA_DATABASE_NAME as "Current Database" FROM %s`, m.Dialector.(Dialector).DummyTableName()),
@@ -20,25 +30,232 @@ func (m Migrator) CurrentDatabase() (name string) {
 	return
 }
 
+func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	return m.columnDefinitionOf(field, true)
+}
+
+func (m Migrator) columnDefinitionOf(field *schema.Field, withIdentity bool) (expr clause.Expr) {
+	expr.SQL = m.DataTypeOf(field)
+
+	column := columnOptionOf(field)
+	if column.Invisible {
+		expr.SQL += " INVISIBLE"
+	}
+
+	if column.Virtual != "" {
+		expr.SQL += " GENERATED ALWAYS AS (" + column.Virtual + ") VIRTUAL"
+	} else if identity, ok := identityOf(field); ok {
+		if withIdentity {
+			expr.SQL += identity.build(false)
+		}
+	} else if field.HasDefaultValue && (field.DefaultValueInterface != nil || field.DefaultValue != "") {
+		// Oracle wants DEFAULT ahead of the inline constraints
+		if field.DefaultValueInterface != nil {
+			expr.SQL += " DEFAULT " + literalOf(field.DefaultValueInterface)
+		} else if field.DefaultValue != "(-)" {
+			expr.SQL += " DEFAULT " + field.DefaultValue
+		}
+	}
+
+	if field.NotNull {
+		expr.SQL += " NOT NULL"
+	}
+
+	if field.Unique {
+		expr.SQL += " UNIQUE"
+	}
+
+	return
+}
+
+func (m Migrator) AutoMigrate(values ...interface{}) error {
+	if err := m.Migrator.AutoMigrate(values...); err != nil {
+		return err
+	}
+
+	for _, value := range m.ReorderModels(values, true) {
+		if err := m.migrateIndexes(value); err != nil {
+			return err
+		}
+
+		if err := m.migrateConstraintStates(value); err != nil {
+			return err
+		}
+
+		if err := m.migrateComments(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m Migrator) CreateTable(values ...interface{}) error {
-	for _, value := range values {
-		m.TryQuotifyReservedWords(value)
-		m.TryRemoveOnUpdate(value)
+	for _, value := range m.ReorderModels(values, false) {
+
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if option, ok := temporaryTableOf(stmt); ok {
+				if err := m.createTemporaryTable(value, stmt, option); err != nil || option.Private {
+					return err
+				}
+			} else {
+				if err := m.createTable(value, stmt); err != nil {
+					return err
+				}
+			}
+
+			if err := m.createSequences(stmt); err != nil {
+				return err
+			}
+
+			if comment, ok := m.tableCommentOf(stmt); ok {
+				if err := m.commentOnTable(stmt, comment); err != nil {
+					return err
+				}
+			}
+
+			for _, dbName := range stmt.Schema.DBNames {
+				if err := m.commentOnColumn(stmt, stmt.Schema.FieldsByDBName[dbName]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m Migrator) createTable(value interface{}, stmt *gorm.Statement) error {
+	tableOptions, err := m.tableOptionsOf(stmt)
+	if err != nil {
+		return err
+	}
+
+	var (
+		definitions             []string
+		values                  = []interface{}{clause.Table{Name: stmt.Table}}
+		hasPrimaryKeyInDataType bool
+	)
+
+	for _, dbName := range stmt.Schema.DBNames {
+		field := stmt.Schema.FieldsByDBName[dbName]
+		hasPrimaryKeyInDataType = hasPrimaryKeyInDataType || strings.Contains(strings.ToUpper(string(field.DataType)), "PRIMARY KEY")
+		definitions = append(definitions, "? ?")
+		values = append(values, clause.Column{Name: dbName}, m.DB.Migrator().FullDataTypeOf(field))
+	}
+
+	if !hasPrimaryKeyInDataType && len(stmt.Schema.PrimaryFields) > 0 {
+		var primaryKeys []interface{}
+		for _, field := range stmt.Schema.PrimaryFields {
+			primaryKeys = append(primaryKeys, clause.Column{Name: field.DBName})
+		}
+		definitions = append(definitions, "PRIMARY KEY ?")
+		values = append(values, primaryKeys)
+	}
+
+	if !m.DB.DisableForeignKeyConstraintWhenMigrating {
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == stmt.Schema {
+				foreignKeySQL, foreignKeyValues, err := m.foreignKeyOf(constraint)
+				if err != nil {
+					return err
+				}
+				definitions = append(definitions, foreignKeySQL)
+				values = append(values, foreignKeyValues...)
+			}
+		}
+	}
+
+	for _, chk := range sortedChecks(stmt) {
+		checkSQL, checkValues, err := m.checkConstraintOf(chk)
+		if err != nil {
+			return err
+		}
+		definitions = append(definitions, checkSQL)
+		values = append(values, checkValues...)
+	}
+
+	if err := m.DB.Exec("CREATE TABLE ? ("+strings.Join(definitions, ",")+")"+tableOptions, values...).Error; err != nil {
+		return err
+	}
+
+	if !m.DB.DisableForeignKeyConstraintWhenMigrating {
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == stmt.Schema && m.onUpdateCascadeOf(constraint) {
+				if err := m.createOnUpdateCascade(stmt, constraint); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, idx := range stmt.Schema.ParseIndexes() {
+		if err := m.DB.Migrator().CreateIndex(value, idx.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableOptionsOf appends the Oracle specific clauses declared by the model to gorm:table_options
+func (m Migrator) tableOptionsOf(stmt *gorm.Statement) (tableOptions string, err error) {
+	storage, ok, err := m.storageOption()
+	if err != nil {
+		return "", err
+	} else if ok {
+		tableOptions += storage.tableClause() + storage.lobClause(m, stmt, stmt.Schema.Fields...)
+	}
+
+	if tableOption, ok := m.DB.Get("gorm:table_options"); ok {
+		tableOptions += " " + strings.TrimSpace(fmt.Sprint(tableOption))
+	}
+
+	model := reflect.New(stmt.Schema.ModelType).Interface()
+	if partitioner, ok := model.(TablePartitioner); ok {
+		partitionSQL, err := partitioner.TablePartition().build(stmt)
+		if err != nil {
+			return "", err
+		}
+		tableOptions += partitionSQL
 	}
-	return m.Migrator.CreateTable(values...)
+	return
 }
 
 func (m Migrator) DropTable(values ...interface{}) error {
+	option, err := m.dropTableOption()
+	if err != nil {
+		return err
+	}
+
+	// DROP TABLE IF EXISTS is new in 23ai
+	ifExists := option.IfExists && m.serverMajorVersion() >= 23
+
 	values = m.ReorderModels(values, false)
 	for i := len(values) - 1; i >= 0; i-- {
 		value := values[i]
 		tx := m.DB.Session(&gorm.Session{})
-		if m.HasTable(value) {
-			if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-				return tx.Exec("DROP TABLE ? CASCADE CONSTRAINTS", clause.Table{Name: stmt.Table}).Error
-			}); err != nil {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			// dropping a missing table is fine, rather than asking for it first
+			if err := tx.Exec(option.build(stmt, ifExists), clause.Table{Name: stmt.Table}).Error; err != nil {
+				if isTableNotFound(err) {
+					return nil
+				}
 				return err
 			}
+
+			// the ON UPDATE CASCADE triggers of its foreign keys live on the referenced tables
+			var cascaded []string
+			if stmt.Schema != nil {
+				for _, rel := range stmt.Schema.Relationships.Relations {
+					if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == stmt.Schema && m.onUpdateCascadeOf(constraint) {
+						cascaded = append(cascaded, constraint.Name)
+					}
+				}
+			}
+			return m.dropOnUpdateCascade(cascaded...)
+		}); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -48,6 +265,13 @@ func (m Migrator) HasTable(value interface{}) bool {
 	var count int64
 
 	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		// private temporary tables of all sessions of the user are listed, only the ones of this session can be used
+		if isPrivateTemporaryTable(stmt.Table) {
+			return m.DB.Raw(
+				"SELECT COUNT(*) FROM USER_PRIVATE_TEMP_TABLES WHERE TABLE_NAME = ? AND SID = SYS_CONTEXT('USERENV', 'SID')",
+				ConvertNameToFormat(stmt.Table),
+			).Row().Scan(&count)
+		}
 		return m.DB.Raw("SELECT COUNT(*) FROM USER_TABLES WHERE TABLE_NAME = ?", stmt.Table).Row().Scan(&count)
 	})
 
@@ -89,11 +313,23 @@ func (m Migrator) RenameTable(oldName, newName interface{}) (err error) {
 
 func (m Migrator) AddColumn(value interface{}, field string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return fmt.Errorf("failed to look up field with name: %s", field)
+		}
+
 		if field := stmt.Schema.LookUpField(field); field != nil {
-			return m.DB.Exec(
-				"ALTER TABLE ? ADD ? ?",
+			storage, _, err := m.storageOption()
+			if err != nil {
+				return err
+			}
+
+			if err := m.DB.Exec(
+				"ALTER TABLE ? ADD (? ?)"+storage.lobClause(m, stmt, field),
 				clause.Table{Name: stmt.Table}, clause.Column{Name: field.DBName}, m.DB.Migrator().FullDataTypeOf(field),
-			).Error
+			).Error; err != nil {
+				return err
+			}
+			return m.commentOnColumn(stmt, field)
 		}
 		return fmt.Errorf("failed to look up field with name: %s", field)
 	})
@@ -105,8 +341,10 @@ func (m Migrator) DropColumn(value interface{}, name string) error {
 	}
 
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		if field := stmt.Schema.LookUpField(name); field != nil {
-			name = field.DBName
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(name); field != nil {
+				name = field.DBName
+			}
 		}
 
 		return m.DB.Exec(
@@ -123,13 +361,40 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 	}
 
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return fmt.Errorf("failed to look up field with name: %s", field)
+		}
+
 		if field := stmt.Schema.LookUpField(field); field != nil {
-			return m.DB.Exec(
+			if isIdentity, err := m.alterIdentity(stmt, field); err != nil || isIdentity {
+				if err != nil {
+					return err
+				}
+				return m.commentOnColumn(stmt, field)
+			}
+
+			// virtual columns hold no data, a rebuild would turn them into stored ones
+			if !isVirtualColumn(field) {
+				if rebuild, err := m.needsRebuild(stmt, field); err != nil || rebuild {
+					if err != nil {
+						return err
+					}
+					if err := m.rebuildColumn(stmt, field); err != nil {
+						return err
+					}
+					return m.commentOnColumn(stmt, field)
+				}
+			}
+
+			if err := m.DB.Exec(
 				"ALTER TABLE ? MODIFY ? ?",
 				clause.Table{Name: stmt.Table},
 				clause.Column{Name: field.DBName},
-				m.FullDataTypeOf(field),
-			).Error
+				m.columnDefinitionOf(field, false),
+			).Error; err != nil {
+				return err
+			}
+			return m.commentOnColumn(stmt, field)
 		}
 		return fmt.Errorf("failed to look up field with name: %s", field)
 	})
@@ -138,115 +403,131 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 func (m Migrator) HasColumn(value interface{}, field string) bool {
 	var count int64
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		return m.DB.Raw("SELECT COUNT(*) FROM USER_TAB_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?", stmt.Table, field).Row().Scan(&count)
+		name := field
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(field); field != nil {
+				name = field.DBName
+			}
+		}
+
+		return m.DB.Raw(
+			"SELECT COUNT(*) FROM USER_TAB_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?",
+			ConvertNameToFormat(stmt.Table), ConvertNameToFormat(name),
+		).Row().Scan(&count)
 	}) == nil && count > 0
 }
 
-func (m Migrator) CreateConstraint(value interface{}, name string) error {
-	m.TryRemoveOnUpdate(value)
-	return m.Migrator.CreateConstraint(value, name)
+// ColumnTypes queries the columns of the table by name, SELECT * would leave invisible columns out
+func (m Migrator) ColumnTypes(value interface{}) (columnTypes []*sql.ColumnType, err error) {
+	err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		var columns []string
+		if err := m.DB.Session(&gorm.Session{}).Table("USER_TAB_COLUMNS").Where("TABLE_NAME = ?", ConvertNameToFormat(stmt.Table)).
+			Order("COLUMN_ID NULLS LAST, COLUMN_NAME").Pluck("COLUMN_NAME", &columns).Error; err != nil {
+			return err
+		}
+
+		tx := m.DB.Session(&gorm.Session{}).Table(stmt.Table)
+		if len(columns) > 0 {
+			tx = tx.Select(columns)
+		}
+		rows, err := tx.Limit(1).Rows()
+		if err == nil {
+			defer rows.Close()
+			columnTypes, err = rows.ColumnTypes()
+		}
+		return err
+	})
+	return
 }
 
-func (m Migrator) DropConstraint(value interface{}, name string) error {
+func (m Migrator) CreateIndex(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		for _, chk := range stmt.Schema.ParseCheckConstraints() {
-			if chk.Name == name {
-				return m.DB.Exec(
-					"ALTER TABLE ? DROP CHECK ?",
-					clause.Table{Name: stmt.Table}, clause.Column{Name: name},
-				).Error
+		if idx := stmt.Schema.LookIndex(name); idx != nil {
+			option, err := m.indexOptionOf(stmt, idx)
+			if err != nil {
+				return err
+			}
+
+			storage, _, err := m.storageOption()
+			if err != nil {
+				return err
 			}
+
+			ddl, err := m.ddlOption()
+			if err != nil {
+				return err
+			}
+			option.Online = option.Online || ddl.Online
+
+			opts := m.BuildIndexOptions(idx.Fields, stmt)
+			values := []interface{}{clause.Column{Name: idx.Name}, clause.Table{Name: stmt.Table}, opts}
+			return m.DB.Exec(option.build(storage), values...).Error
 		}
 
-		return m.DB.Exec(
-			"ALTER TABLE ? DROP CONSTRAINT ?",
-			clause.Table{Name: stmt.Table}, clause.Column{Name: name},
-		).Error
+		return fmt.Errorf("failed to create index with name %v", name)
 	})
 }
 
-func (m Migrator) HasConstraint(value interface{}, name string) bool {
-	var count int64
-	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		return m.DB.Raw(
-			"SELECT COUNT(*) FROM USER_CONSTRAINTS WHERE TABLE_NAME = ? AND CONSTRAINT_NAME = ?", stmt.Table, name,
-		).Row().Scan(&count)
-	}) == nil && count > 0
-}
-
 func (m Migrator) DropIndex(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		if idx := stmt.Schema.LookIndex(name); idx != nil {
-			name = idx.Name
+		ddl, err := m.ddlOption()
+		if err != nil {
+			return err
 		}
 
-		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: name}, clause.Table{Name: stmt.Table}).Error
+		owner, index := m.indexNameOf(stmt, name)
+		if owner != "" {
+			index = owner + "." + index
+		}
+
+		if ddl.Online {
+			return m.DB.Exec("DROP INDEX ? ONLINE", clause.Column{Name: index}).Error
+		}
+		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: index}).Error
 	})
 }
 
+// HasIndex takes a field name, an index name or an owner qualified index name like HR.IDX_USERS_EMAIL
 func (m Migrator) HasIndex(value interface{}, name string) bool {
 	var count int64
 	m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		if idx := stmt.Schema.LookIndex(name); idx != nil {
-			name = idx.Name
+		owner, index := m.indexNameOf(stmt, name)
+		if owner != "" {
+			return m.DB.Raw(
+				"SELECT COUNT(*) FROM ALL_INDEXES WHERE OWNER = ? AND INDEX_NAME = ? AND TABLE_NAME = ?",
+				owner, index, ConvertNameToFormat(stmt.Table),
+			).Row().Scan(&count)
 		}
 
 		return m.DB.Raw(
-			"SELECT COUNT(*) FROM USER_INDEXES WHERE TABLE_NAME = ? AND INDEX_NAME = ?",
-			m.Migrator.DB.NamingStrategy.TableName(stmt.Table),
-			m.Migrator.DB.NamingStrategy.IndexName(stmt.Table, name),
+			"SELECT COUNT(*) FROM USER_INDEXES WHERE INDEX_NAME = ? AND TABLE_NAME = ?",
+			index, ConvertNameToFormat(stmt.Table),
 		).Row().Scan(&count)
 	})
 
 	return count > 0
 }
 
-// https://docs.oracle.com/database/121/SPATL/alter-index-rename.htm
 func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error {
-	panic("TODO")
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		// the new name can't be owner qualified, the index stays in its schema
+		owner, index := m.indexNameOf(stmt, oldName)
+		if owner != "" {
+			index = owner + "." + index
+		}
 		return m.DB.Exec(
-			"ALTER INDEX ?.? RENAME TO ?", // wat
-			clause.Table{Name: stmt.Table}, clause.Column{Name: oldName}, clause.Column{Name: newName},
+			"ALTER INDEX ? RENAME TO ?",
+			clause.Column{Name: index}, clause.Column{Name: ConvertNameToFormat(newName)},
 		).Error
 	})
 }
 
+// Deprecated: ON UPDATE actions are left out of the foreign keys without touching the schema, see Config.EmulateOnUpdateCascade
 func (m Migrator) TryRemoveOnUpdate(values ...interface{}) error {
-	for _, value := range values {
-		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-			for _, rel := range stmt.Schema.Relationships.Relations {
-				constraint := rel.ParseConstraint()
-				if constraint != nil {
-					rel.Field.TagSettings["CONSTRAINT"] = strings.ReplaceAll(rel.Field.TagSettings["CONSTRAINT"], fmt.Sprintf("ON UPDATE %s", constraint.OnUpdate), "")
-				}
-			}
-			return nil
-		}); err != nil {
-			return err
-		}
-	}
 	return nil
 }
 
+// Deprecated: reserved words are quoted by Dialector.QuoteTo, the parsed schema is left untouched
 func (m Migrator) TryQuotifyReservedWords(values ...interface{}) error {
-	for _, value := range values {
-		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-			for idx, v := range stmt.Schema.DBNames {
-				if IsReservedWord(v) {
-					stmt.Schema.DBNames[idx] = fmt.Sprintf(`"%s"`, v)
-				}
-			}
-
-			for _, v := range stmt.Schema.Fields {
-				if IsReservedWord(v.DBName) {
-					v.DBName = fmt.Sprintf(`"%s"`, v.DBName)
-				}
-			}
-			return nil
-		}); err != nil {
-			return err
-		}
-	}
 	return nil
 }
@@ -0,0 +1,31 @@
+package oracle
+
+import (
+	"testing"
+)
+
+func TestWithOptionsKeepSettings(t *testing.T) {
+	db, _ := openFakeDB(t, Config{}, nil)
+
+	base := db.Migrator().(Migrator)
+	m := base.WithStorage(StorageOption{Tablespace: "USERS"}).
+		WithDDL(DDLOption{Online: true}).
+		WithDropTable(DropTableOption{Purge: true}).
+		WithStorage(StorageOption{Tablespace: "DATA"})
+
+	if option, ok, err := m.storageOption(); !ok || err != nil || option.Tablespace != "DATA" {
+		t.Errorf("expected the latest storage option, got %+v, %v, %v", option, ok, err)
+	}
+	if option, err := m.ddlOption(); err != nil || !option.Online {
+		t.Errorf("expected the DDL option to be kept, got %+v, %v", option, err)
+	}
+	if option, err := m.dropTableOption(); err != nil || !option.Purge {
+		t.Errorf("expected the drop table option to be kept, got %+v, %v", option, err)
+	}
+
+	for _, key := range []string{storageOptionKey, ddlOptionKey, dropTableOptionKey} {
+		if _, ok := base.DB.Get(key); ok {
+			t.Errorf("expected the original migrator to be left without %s", key)
+		}
+	}
+}
@@ -1,10 +1,16 @@
 package oracle
 
 import (
-	"gorm.io/gorm/schema"
+	"fmt"
+	"hash/fnv"
 	"strings"
+
+	"gorm.io/gorm/schema"
 )
 
+// maxIdentifierLength is the identifier limit in bytes before 12.2
+const maxIdentifierLength = 30
+
 type Namer struct {
 	schema.NamingStrategy
 }
@@ -13,6 +19,17 @@ func ConvertNameToFormat(x string) string {
 	return strings.ToUpper(x)
 }
 
+// limitIdentifier shortens generated names over 30 bytes, keeping a prefix and a hash of the whole name apart
+func limitIdentifier(name string) string {
+	if len(name) <= maxIdentifierLength {
+		return name
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	return fmt.Sprintf("%s_%08X", name[:maxIdentifierLength-9], hash.Sum32())
+}
+
 func (n Namer) TableName(table string) (name string) {
 	return ConvertNameToFormat(n.NamingStrategy.TableName(table))
 }
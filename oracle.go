@@ -1,19 +1,17 @@
 package oracle
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"gorm.io/gorm/utils"
-	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	_ "github.com/godror/godror"
-	"github.com/thoas/go-funk"
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
-	"gorm.io/gorm/logger"
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
 )
@@ -23,8 +21,31 @@ type Config struct {
 	DSN               string
 	Conn              *sql.DB
 	DefaultStringSize uint
+	// DurationAsInterval maps time.Duration fields to INTERVAL DAY TO SECOND instead of INTEGER
+	DurationAsInterval bool
+	// MaxStringSize is the largest VARCHAR2 size before strings become CLOB,
+	// detected on first use when zero, 32767 on servers with MAX_STRING_SIZE = EXTENDED
+	MaxStringSize uint
+	// LengthSemantics is BYTE or CHAR, the session default is used when empty
+	LengthSemantics string
+	// RefreshReservedWords loads the reserved words of the server from V$RESERVED_WORDS on open
+	RefreshReservedWords bool
+	// EmulateOnUpdateCascade creates a trigger for `constraint:OnUpdate:CASCADE` foreign keys,
+	// which become DEFERRABLE INITIALLY DEFERRED unless their constraintState says otherwise
+	EmulateOnUpdateCascade bool
+
+	maxStringSizeDetector *maxStringSizeDetector
 }
 
+const (
+	ByteSemantics = "BYTE"
+	CharSemantics = "CHAR"
+
+	defaultStringSize     = 1024
+	standardMaxStringSize = 4000
+	extendedMaxStringSize = 32767
+)
+
 type Dialector struct {
 	*Config
 }
@@ -46,8 +67,13 @@ func (d Dialector) Name() string {
 }
 
 func (d Dialector) Initialize(db *gorm.DB) (err error) {
+	switch strings.ToUpper(d.LengthSemantics) {
+	case "", ByteSemantics, CharSemantics:
+	default:
+		return fmt.Errorf("invalid length semantics %q, expect %s or %s", d.LengthSemantics, ByteSemantics, CharSemantics)
+	}
+
 	db.NamingStrategy = Namer{}
-	d.DefaultStringSize = 1024
 
 	// register callbacks
 	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{WithReturning: true})
@@ -56,20 +82,77 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 
 	if d.Conn != nil {
 		db.ConnPool = d.Conn
-	} else {
-		db.ConnPool, err = sql.Open(d.DriverName, d.DSN)
+	} else if db.ConnPool, err = sql.Open(d.DriverName, d.DSN); err != nil {
+		return
+	}
+
+	if d.MaxStringSize == 0 {
+		d.maxStringSizeDetector = &maxStringSizeDetector{db: db}
+	}
+
+	if d.RefreshReservedWords {
+		if err = refreshReservedWords(context.Background(), db.ConnPool); err != nil {
+			return fmt.Errorf("failed to load reserved words: %w", err)
+		}
 	}
 
 	if err = db.Callback().Create().Replace("gorm:create", Create); err != nil {
 		return
 	}
 
+	if err = db.Callback().Query().Replace("gorm:query", Query); err != nil {
+		return
+	}
+
+	if err = db.Callback().Create().Before("gorm:create").Register("oracle:omit_virtual_columns", OmitVirtualColumns); err != nil {
+		return
+	}
+
+	if err = db.Callback().Update().Before("gorm:update").Register("oracle:omit_virtual_columns", OmitVirtualColumns); err != nil {
+		return
+	}
+
 	for k, v := range d.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
 	}
 	return
 }
 
+// maxStringSizeDetector probes the max string size once it's needed, rather than while opening the database
+type maxStringSizeDetector struct {
+	once          sync.Once
+	db            *gorm.DB
+	maxStringSize int
+}
+
+// detect checks whether RPAD can build a string over 4000 bytes, which only works with MAX_STRING_SIZE = EXTENDED,
+// unlike V$PARAMETER any user can run it
+func (detector *maxStringSizeDetector) detect() int {
+	detector.once.Do(func() {
+		detector.maxStringSize = standardMaxStringSize
+
+		var length int
+		if err := detector.db.ConnPool.QueryRowContext(
+			context.Background(), "SELECT LENGTHB(RPAD('x', 4001, 'x')) FROM DUAL",
+		).Scan(&length); err != nil {
+			detector.db.Logger.Warn(context.Background(), "failed to detect the max string size, assuming %d: %v", standardMaxStringSize, err)
+		} else if length > standardMaxStringSize {
+			detector.maxStringSize = extendedMaxStringSize
+		}
+	})
+	return detector.maxStringSize
+}
+
+func (d Dialector) maxStringSize() int {
+	switch {
+	case d.MaxStringSize > 0:
+		return int(d.MaxStringSize)
+	case d.maxStringSizeDetector != nil:
+		return d.maxStringSizeDetector.detect()
+	}
+	return standardMaxStringSize
+}
+
 func (d Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
 	return map[string]clause.ClauseBuilder{
 		"LIMIT": d.RewriteLimit,
@@ -127,24 +210,26 @@ func (d Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v inter
 	writer.WriteString(strconv.Itoa(len(stmt.Vars)))
 }
 
+// QuoteTo leaves identifiers unquoted so Oracle folds them to upper case, except reserved words like LEVEL or DATE,
+// which are quoted in upper case to match the unquoted names stored in the data dictionary
 func (d Dialector) QuoteTo(writer clause.Writer, str string) {
-	writer.WriteString(str)
-}
+	for idx, name := range strings.Split(str, ".") {
+		if idx > 0 {
+			writer.WriteByte('.')
+		}
 
-var numericPlaceholder = regexp.MustCompile(`:(\d+)`)
+		if IsReservedWord(ConvertNameToFormat(name)) {
+			writer.WriteByte('"')
+			writer.WriteString(ConvertNameToFormat(name))
+			writer.WriteByte('"')
+		} else {
+			writer.WriteString(name)
+		}
+	}
+}
 
 func (d Dialector) Explain(sql string, vars ...interface{}) string {
-	return logger.ExplainSQL(sql, numericPlaceholder, `'`, funk.Map(vars, func(v interface{}) interface{} {
-		switch v := v.(type) {
-		case bool:
-			if v {
-				return 1
-			}
-			return 0
-		default:
-			return v
-		}
-	}).([]interface{})...)
+	return inlineVars(sql, vars...)
 }
 
 func (d Dialector) DataTypeOf(field *schema.Field) string {
@@ -154,7 +239,14 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 
 	var sqlType string
 
-	switch field.DataType {
+	dataType := field.DataType
+	if isIntervalDayToSecond(d, field) {
+		dataType = IntervalDayToSecond
+	}
+
+	switch dataType {
+	case IntervalDayToSecond, IntervalYearToMonth:
+		sqlType = intervalDataTypeOf(dataType, field)
 	case schema.Bool, schema.Int, schema.Uint, schema.Float:
 		sqlType = "INTEGER"
 
@@ -165,36 +257,10 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 			sqlType = "SMALLINT"
 		}
 
-		if val, ok := field.TagSettings["AUTOINCREMENT"]; ok && utils.CheckTruth(val) {
-			sqlType += " GENERATED BY DEFAULT AS IDENTITY"
-		}
 	case schema.String, "VARCHAR2":
-		size := field.Size
-		defaultSize := d.DefaultStringSize
-
-		if size == 0 {
-			if defaultSize > 0 {
-				size = int(defaultSize)
-			} else {
-				hasIndex := field.TagSettings["INDEX"] != "" || field.TagSettings["UNIQUE"] != ""
-				// TEXT, GEOMETRY or JSON column can't have a default value
-				if field.PrimaryKey || field.HasDefaultValue || hasIndex {
-					size = 191 // utf8mb4
-				}
-			}
-		}
-
-		if size >= 2000 {
-			sqlType = "CLOB"
-		} else {
-			sqlType = fmt.Sprintf("VARCHAR2(%d)", size)
-		}
-
+		sqlType = d.stringDataTypeOf(field)
 	case schema.Time:
 		sqlType = "TIMESTAMP WITH TIME ZONE"
-		if field.NotNull || field.PrimaryKey {
-			sqlType += " NOT NULL"
-		}
 	case schema.Bytes:
 		sqlType = "BLOB"
 	default:
@@ -202,27 +268,70 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 
 		if strings.EqualFold(sqlType, "text") {
 			sqlType = "CLOB"
+			if _, ok := field.TagSettings["NATIONAL"]; ok {
+				sqlType = "NCLOB"
+			}
 		}
 
 		if sqlType == "" {
 			panic(fmt.Sprintf("invalid sql type %s (%s) for oracle", field.FieldType.Name(), field.FieldType.String()))
 		}
+	}
 
-		notNull, _ := field.TagSettings["NOT NULL"]
-		unique, _ := field.TagSettings["UNIQUE"]
-		additionalType := fmt.Sprintf("%s %s", notNull, unique)
-		if value, ok := field.TagSettings["DEFAULT"]; ok {
-			additionalType = fmt.Sprintf("%s %s %s%s", "DEFAULT", value, additionalType, func() string {
-				if value, ok := field.TagSettings["COMMENT"]; ok {
-					return " COMMENT " + value
-				}
-				return ""
-			}())
+	return sqlType
+}
+
+// stringDataTypeOf picks VARCHAR2/CLOB, or NVARCHAR2/NCLOB for fields tagged with NATIONAL
+//
+// The size tag wins, then Config.DefaultStringSize, then 1024. Strings larger than the server's
+// max string size become LOBs, except for primary key, unique and indexed columns, which can't
+// be LOBs and are capped to the largest indexable size instead.
+func (d Dialector) stringDataTypeOf(field *schema.Field) string {
+	_, national := field.TagSettings["NATIONAL"]
+
+	maxSize := d.maxStringSize()
+	if national {
+		// NVARCHAR2 sizes are always characters, at most two bytes each in AL16UTF16
+		maxSize /= 2
+	}
+
+	size := field.Size
+	if size <= 0 {
+		size = defaultStringSize
+		if d.DefaultStringSize > 0 {
+			size = int(d.DefaultStringSize)
 		}
-		sqlType = fmt.Sprintf("%v %v", sqlType, additionalType)
 	}
 
-	return sqlType
+	if isKeyField(field) {
+		keySize := standardMaxStringSize
+		if national {
+			keySize /= 2
+		}
+		if keySize > maxSize {
+			keySize = maxSize
+		}
+		if size > keySize {
+			size = keySize
+		}
+	}
+
+	switch {
+	case size > maxSize && national:
+		return "NCLOB"
+	case size > maxSize:
+		return "CLOB"
+	case national:
+		return fmt.Sprintf("NVARCHAR2(%d)", size)
+	case d.LengthSemantics != "":
+		return fmt.Sprintf("VARCHAR2(%d %s)", size, strings.ToUpper(d.LengthSemantics))
+	}
+	return fmt.Sprintf("VARCHAR2(%d)", size)
+}
+
+func isKeyField(field *schema.Field) bool {
+	return field.PrimaryKey || field.Unique ||
+		field.TagSettings["INDEX"] != "" || field.TagSettings["UNIQUEINDEX"] != "" || field.TagSettings["UNIQUE"] != ""
 }
 
 func (d Dialector) SavePoint(tx *gorm.DB, name string) error {
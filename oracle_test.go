@@ -0,0 +1,105 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestDetectMaxStringSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		result fakeResult
+		want   string
+	}{
+		{"extended", fakeResult{Columns: []string{"LENGTH"}, Rows: [][]driver.Value{{int64(4001)}}}, "VARCHAR2(8000)"},
+		{"standard", fakeResult{Columns: []string{"LENGTH"}, Rows: [][]driver.Value{{int64(4000)}}}, "CLOB"},
+		{"failed", fakeResult{}, "CLOB"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+				if query == "SELECT LENGTHB(RPAD('x', 4001, 'x')) FROM DUAL" {
+					return test.result
+				}
+				return fakeResult{}
+			})
+			if len(fake.Statements()) != 0 {
+				t.Fatalf("expected no probe while opening, got %q", fake.SQL())
+			}
+
+			field := &schema.Field{DataType: schema.String, Size: 8000, TagSettings: map[string]string{}}
+			for i := 0; i < 2; i++ {
+				if got := db.Dialector.DataTypeOf(field); got != test.want {
+					t.Errorf("DataTypeOf() = %q, want %q", got, test.want)
+				}
+			}
+			if len(fake.Statements()) != 1 {
+				t.Errorf("expected a single probe, got %q", fake.SQL())
+			}
+		})
+	}
+}
+
+type stringSizing struct {
+	ID          string `gorm:"primaryKey;size:8000"`
+	Plain       string
+	Sized       string `gorm:"size:100"`
+	Large       string `gorm:"size:5000"`
+	Huge        string `gorm:"size:40000"`
+	Indexed     string `gorm:"index;size:8000"`
+	Unique      string `gorm:"unique;size:8000"`
+	UniqueIndex string `gorm:"uniqueIndex;size:8000"`
+	National    string `gorm:"national;size:100"`
+	NationalBig string `gorm:"national;size:3000"`
+	NationalKey string `gorm:"national;index;size:8000"`
+}
+
+func TestStringDataTypeOf(t *testing.T) {
+	parsed, err := schema.Parse(&stringSizing{}, &sync.Map{}, Namer{})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		field  string
+		want   string
+	}{
+		{"default size", Config{}, "Plain", "VARCHAR2(1024)"},
+		{"configured default size", Config{DefaultStringSize: 255}, "Plain", "VARCHAR2(255)"},
+		{"configured default size over max", Config{DefaultStringSize: 5000}, "Plain", "CLOB"},
+		{"size tag wins", Config{DefaultStringSize: 255}, "Sized", "VARCHAR2(100)"},
+		{"over standard max", Config{}, "Large", "CLOB"},
+		{"within extended max", Config{MaxStringSize: 32767}, "Large", "VARCHAR2(5000)"},
+		{"over extended max", Config{MaxStringSize: 32767}, "Huge", "CLOB"},
+		{"primary key capped", Config{}, "ID", "VARCHAR2(4000)"},
+		{"primary key capped on extended", Config{MaxStringSize: 32767}, "ID", "VARCHAR2(4000)"},
+		{"index capped", Config{}, "Indexed", "VARCHAR2(4000)"},
+		{"unique capped", Config{}, "Unique", "VARCHAR2(4000)"},
+		{"unique index capped", Config{}, "UniqueIndex", "VARCHAR2(4000)"},
+		{"key capped to smaller max", Config{MaxStringSize: 2000}, "Indexed", "VARCHAR2(2000)"},
+		{"byte semantics", Config{LengthSemantics: "byte"}, "Sized", "VARCHAR2(100 BYTE)"},
+		{"char semantics", Config{LengthSemantics: CharSemantics}, "Sized", "VARCHAR2(100 CHAR)"},
+		{"char semantics on capped key", Config{LengthSemantics: CharSemantics}, "Indexed", "VARCHAR2(4000 CHAR)"},
+		{"national", Config{}, "National", "NVARCHAR2(100)"},
+		{"national ignores semantics", Config{LengthSemantics: CharSemantics}, "National", "NVARCHAR2(100)"},
+		{"national over standard max", Config{}, "NationalBig", "NCLOB"},
+		{"national within extended max", Config{MaxStringSize: 32767}, "NationalBig", "NVARCHAR2(3000)"},
+		{"national key capped", Config{MaxStringSize: 32767}, "NationalKey", "NVARCHAR2(2000)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := test.config
+			dialector := Dialector{Config: &config}
+			if got := dialector.DataTypeOf(parsed.LookUpField(test.field)); got != test.want {
+				t.Errorf("DataTypeOf(%s) = %q, want %q", test.field, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TablePartitioner is implemented by models whose tables are partitioned, e.g.
+//
+//	func (Event) TablePartition() oracle.PartitionOption {
+//		return oracle.PartitionOption{
+//			Type:       oracle.PartitionByRange,
+//			Columns:    []string{"CreatedAt"},
+//			Interval:   "NUMTOYMINTERVAL(1,'MONTH')",
+//			Partitions: []oracle.Partition{{Name: "P0", Values: "TIMESTAMP '2020-01-01 00:00:00'"}},
+//		}
+//	}
+type TablePartitioner interface {
+	TablePartition() PartitionOption
+}
+
+const (
+	PartitionByRange = "RANGE"
+	PartitionByList  = "LIST"
+	PartitionByHash  = "HASH"
+)
+
+type PartitionOption struct {
+	Type string
+	// Columns are field names or column names of the partition key
+	Columns []string
+	// Interval turns range partitioning into interval partitioning
+	Interval   string
+	Partitions []Partition
+	// Count creates that many hash partitions when Partitions is empty
+	Count int
+}
+
+type Partition struct {
+	Name string
+	// Values is the upper bound for range partitions or the value list for list partitions
+	Values     string
+	Tablespace string
+}
+
+type PartitionInfo struct {
+	Name           string
+	Position       int
+	HighValue      string
+	TablespaceName string
+}
+
+func (partition Partition) build(partitionType string) (partitionSQL string) {
+	partitionSQL = "PARTITION " + partition.Name
+	switch partitionType {
+	case PartitionByRange:
+		partitionSQL += " VALUES LESS THAN (" + partition.Values + ")"
+	case PartitionByList:
+		partitionSQL += " VALUES (" + partition.Values + ")"
+	}
+	if partition.Tablespace != "" {
+		partitionSQL += " TABLESPACE " + partition.Tablespace
+	}
+	return
+}
+
+func (option PartitionOption) build(stmt *gorm.Statement) (string, error) {
+	partitionType := strings.ToUpper(option.Type)
+	switch partitionType {
+	case PartitionByRange, PartitionByList, PartitionByHash:
+	default:
+		return "", fmt.Errorf("invalid partition type %q for table %s, expect RANGE, LIST or HASH", option.Type, stmt.Table)
+	}
+
+	if len(option.Columns) == 0 {
+		return "", fmt.Errorf("partition key of table %s is required", stmt.Table)
+	}
+
+	if option.Interval != "" && partitionType != PartitionByRange {
+		return "", fmt.Errorf("interval partitioning of table %s requires RANGE partitioning", stmt.Table)
+	}
+
+	if partitionType != PartitionByHash && len(option.Partitions) == 0 {
+		return "", fmt.Errorf("%s partitioning of table %s requires at least one partition", partitionType, stmt.Table)
+	}
+
+	columns := make([]string, 0, len(option.Columns))
+	for _, name := range option.Columns {
+		if field := stmt.Schema.LookUpField(name); field != nil {
+			name = field.DBName
+		}
+		columns = append(columns, stmt.Quote(name))
+	}
+
+	partitionSQL := fmt.Sprintf(" PARTITION BY %s (%s)", partitionType, strings.Join(columns, ","))
+	if option.Interval != "" {
+		partitionSQL += " INTERVAL (" + option.Interval + ")"
+	}
+
+	if len(option.Partitions) > 0 {
+		partitions := make([]string, 0, len(option.Partitions))
+		for _, partition := range option.Partitions {
+			partitions = append(partitions, partition.build(partitionType))
+		}
+		partitionSQL += " (" + strings.Join(partitions, ", ") + ")"
+	} else if option.Count > 0 {
+		partitionSQL += fmt.Sprintf(" PARTITIONS %d", option.Count)
+	}
+	return partitionSQL, nil
+}
+
+func (m Migrator) GetPartitions(value interface{}) (partitions []PartitionInfo, err error) {
+	err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		rows, err := m.DB.Raw(
+			"SELECT PARTITION_NAME, PARTITION_POSITION, HIGH_VALUE, TABLESPACE_NAME FROM USER_TAB_PARTITIONS "+
+				"WHERE TABLE_NAME = ? ORDER BY PARTITION_POSITION",
+			ConvertNameToFormat(stmt.Table),
+		).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				partition             PartitionInfo
+				highValue, tablespace sql.NullString
+			)
+			if err := rows.Scan(&partition.Name, &partition.Position, &highValue, &tablespace); err != nil {
+				return err
+			}
+			partition.HighValue, partition.TablespaceName = highValue.String, tablespace.String
+			partitions = append(partitions, partition)
+		}
+		return rows.Err()
+	})
+	return
+}
+
+// AddPartition adds a range or list partition, hash partitioned tables take a partition with Name and Tablespace only.
+// Interval partitioned tables create their partitions on insert and can't take one (ORA-14760)
+func (m Migrator) AddPartition(value interface{}, partition Partition) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		var (
+			partitionType string
+			interval      sql.NullString
+		)
+		if err := m.DB.Raw(
+			"SELECT PARTITIONING_TYPE, INTERVAL FROM USER_PART_TABLES WHERE TABLE_NAME = ?", ConvertNameToFormat(stmt.Table),
+		).Row().Scan(&partitionType, &interval); err != nil {
+			return err
+		}
+		if interval.String != "" {
+			return fmt.Errorf("table %s is interval partitioned, its partitions are created on insert", stmt.Table)
+		}
+		return m.DB.Exec("ALTER TABLE ? ADD "+partition.build(partitionType), clause.Table{Name: stmt.Table}).Error
+	})
+}
+
+func (m Migrator) DropPartition(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec(
+			"ALTER TABLE ? DROP PARTITION ? UPDATE INDEXES", clause.Table{Name: stmt.Table}, clause.Column{Name: name},
+		).Error
+	})
+}
+
+func (m Migrator) TruncatePartition(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec(
+			"ALTER TABLE ? TRUNCATE PARTITION ? UPDATE INDEXES", clause.Table{Name: stmt.Table}, clause.Column{Name: name},
+		).Error
+	})
+}
+
+// SplitPartition splits a range partition at the given bound into two new partitions
+func (m Migrator) SplitPartition(value interface{}, name, at string, into [2]string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec(
+			fmt.Sprintf("ALTER TABLE ? SPLIT PARTITION ? AT (%s) INTO (PARTITION ?, PARTITION ?) UPDATE INDEXES", at),
+			clause.Table{Name: stmt.Table}, clause.Column{Name: name}, clause.Column{Name: into[0]}, clause.Column{Name: into[1]},
+		).Error
+	})
+}
+
+// ExchangePartition swaps the segment of a partition with a non-partitioned table, e.g. to publish a staged load
+func (m Migrator) ExchangePartition(value interface{}, name string, table interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.RunWithValue(table, func(exchange *gorm.Statement) error {
+			return m.DB.Exec(
+				"ALTER TABLE ? EXCHANGE PARTITION ? WITH TABLE ? INCLUDING INDEXES WITHOUT VALIDATION",
+				clause.Table{Name: stmt.Table}, clause.Column{Name: name}, clause.Table{Name: exchange.Table},
+			).Error
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type partitionedEvent struct {
+	ID        uint
+	Region    string `gorm:"size:10"`
+	CreatedAt int64
+}
+
+func TestPartitionOptionBuild(t *testing.T) {
+	db, _ := openFakeDB(t, Config{}, nil)
+	parsed, err := schema.Parse(&partitionedEvent{}, &sync.Map{}, Namer{})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	stmt := &gorm.Statement{DB: db, Schema: parsed, Table: parsed.Table}
+
+	tests := []struct {
+		name   string
+		option PartitionOption
+		want   string
+		err    string
+	}{
+		{
+			name: "interval",
+			option: PartitionOption{
+				Type: "range", Columns: []string{"CreatedAt"}, Interval: "1000",
+				Partitions: []Partition{{Name: "P0", Values: "0"}},
+			},
+			want: " PARTITION BY RANGE (CREATED_AT) INTERVAL (1000) (PARTITION P0 VALUES LESS THAN (0))",
+		},
+		{
+			name: "list",
+			option: PartitionOption{
+				Type: PartitionByList, Columns: []string{"REGION"},
+				Partitions: []Partition{{Name: "P_EU", Values: "'EU'", Tablespace: "EU_DATA"}, {Name: "P_US", Values: "'US'"}},
+			},
+			want: " PARTITION BY LIST (REGION) (PARTITION P_EU VALUES ('EU') TABLESPACE EU_DATA, PARTITION P_US VALUES ('US'))",
+		},
+		{
+			name:   "hash",
+			option: PartitionOption{Type: PartitionByHash, Columns: []string{"ID"}, Count: 4},
+			want:   " PARTITION BY HASH (ID) PARTITIONS 4",
+		},
+		{
+			name:   "invalid type",
+			option: PartitionOption{Type: "REFERENCE", Columns: []string{"ID"}},
+			err:    `invalid partition type "REFERENCE" for table PARTITIONED_EVENTS, expect RANGE, LIST or HASH`,
+		},
+		{
+			name:   "missing key",
+			option: PartitionOption{Type: PartitionByHash, Count: 4},
+			err:    "partition key of table PARTITIONED_EVENTS is required",
+		},
+		{
+			name:   "interval of list",
+			option: PartitionOption{Type: PartitionByList, Columns: []string{"Region"}, Interval: "1"},
+			err:    "interval partitioning of table PARTITIONED_EVENTS requires RANGE partitioning",
+		},
+		{
+			name:   "range without partitions",
+			option: PartitionOption{Type: PartitionByRange, Columns: []string{"CreatedAt"}},
+			err:    "RANGE partitioning of table PARTITIONED_EVENTS requires at least one partition",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.option.build(stmt)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Errorf("build() error = %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Errorf("build() = %q, %v, want %q", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestPartitionsOfTableName(t *testing.T) {
+	var interval driver.Value
+	db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+		if args[0] != "EVENTS" {
+			t.Errorf("expected the upper case table name, got %v for %s", args, query)
+		}
+		switch {
+		case hasPrefixFold(query, "SELECT PARTITION_NAME"):
+			return fakeResult{
+				Columns: []string{"PARTITION_NAME", "PARTITION_POSITION", "HIGH_VALUE", "TABLESPACE_NAME"},
+				Rows:    [][]driver.Value{{"P_EU", int64(1), "'EU'", "USERS"}},
+			}
+		case hasPrefixFold(query, "SELECT PARTITIONING_TYPE"):
+			return fakeResult{Columns: []string{"PARTITIONING_TYPE", "INTERVAL"}, Rows: [][]driver.Value{{PartitionByList, interval}}}
+		}
+		return fakeResult{}
+	})
+	m := db.Migrator().(Migrator)
+
+	partitions, err := m.GetPartitions("events")
+	if err != nil || len(partitions) != 1 || partitions[0] != (PartitionInfo{Name: "P_EU", Position: 1, HighValue: "'EU'", TablespaceName: "USERS"}) {
+		t.Errorf("GetPartitions() = %+v, %v", partitions, err)
+	}
+
+	if err := m.AddPartition("events", Partition{Name: "P_US", Values: "'US'"}); err != nil {
+		t.Fatalf("failed to add partition: %v", err)
+	}
+	if sql := fake.SQL(); sql[len(sql)-1] != "ALTER TABLE events ADD PARTITION P_US VALUES ('US')" {
+		t.Errorf("got %q", sql)
+	}
+
+	fake.Reset()
+	interval = "NUMTOYMINTERVAL(1,'MONTH')"
+	if err := m.AddPartition("events", Partition{Name: "P_US", Values: "'US'"}); err == nil {
+		t.Errorf("expected interval partitioned tables to be rejected")
+	}
+	if sql := fake.SQL(); len(sql) != 1 {
+		t.Errorf("expected no DDL, got %q", sql)
+	}
+}
@@ -0,0 +1,87 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Plan is the DDL AutoMigrate would run, in order, see Migrator.Plan
+type Plan struct {
+	Statements []string
+}
+
+// Plan compares values against the data dictionary like AutoMigrate, but records the DDL instead of running it
+//
+//	plan, err := db.Migrator().(oracle.Migrator).Plan(&User{}, &Order{})
+//	file, _ := os.Create("migrate.sql")
+//	plan.WriteTo(file)
+func (m Migrator) Plan(values ...interface{}) (*Plan, error) {
+	plan := &Plan{}
+	return plan, m.recording(plan).AutoMigrate(values...)
+}
+
+// recording returns a Migrator whose DDL is recorded into plan
+func (m Migrator) recording(plan *Plan) Migrator {
+	ctx := m.DB.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx := m.DB.Session(&gorm.Session{Context: ctx})
+	tx.Statement.ConnPool = planConnPool{ConnPool: tx.Statement.ConnPool, plan: plan}
+	return tx.Migrator().(Migrator)
+}
+
+// Script renders the plan as a SQL*Plus script, PL/SQL blocks are terminated by a slash
+func (plan *Plan) Script() string {
+	var script strings.Builder
+	script.WriteString("SET DEFINE OFF\nWHENEVER SQLERROR EXIT FAILURE\n\n")
+	for _, statement := range plan.Statements {
+		statement = strings.TrimSpace(statement)
+		if isPLSQLBlock(statement) {
+			script.WriteString(statement + "\n/\n\n")
+		} else {
+			script.WriteString(strings.TrimSuffix(statement, ";") + ";\n\n")
+		}
+	}
+	return script.String()
+}
+
+func (plan *Plan) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, plan.Script())
+	return int64(n), err
+}
+
+func isPLSQLBlock(statement string) bool {
+	statement = strings.Join(strings.Fields(strings.ToUpper(statement)), " ")
+	for _, prefix := range []string{"BEGIN", "DECLARE", "CREATE TRIGGER", "CREATE OR REPLACE TRIGGER"} {
+		if strings.HasPrefix(statement, prefix+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// planConnPool records statements instead of executing them, queries still read the live dictionary
+type planConnPool struct {
+	gorm.ConnPool
+	plan *Plan
+}
+
+func (pool planConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if len(args) > 0 {
+		query = inlineVars(query, args...)
+	}
+	pool.plan.Statements = append(pool.plan.Statements, query)
+	return planResult{}, nil
+}
+
+type planResult struct{}
+
+func (planResult) LastInsertId() (int64, error) { return 0, nil }
+
+func (planResult) RowsAffected() (int64, error) { return 0, nil }
@@ -0,0 +1,39 @@
+package oracle
+
+import (
+	"strings"
+	"testing"
+)
+
+type plannedRow struct {
+	ID   uint
+	Name string `gorm:"size:100;comment:it's :1 of them"`
+}
+
+func TestPlanInlinesVarsAsLiterals(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, func(query string, args []interface{}) fakeResult {
+		if hasPrefixFold(query, "SELECT COUNT(*)") {
+			return countResult(0)
+		}
+		return fakeResult{}
+	})
+
+	plan, err := db.Migrator().(Migrator).Plan(&plannedRow{})
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+
+	for _, statement := range fake.SQL() {
+		if !hasPrefixFold(statement, "SELECT") {
+			t.Errorf("expected the plan not to run DDL, got %q", statement)
+		}
+	}
+
+	want := "COMMENT ON COLUMN PLANNED_ROWS.NAME IS 'it''s :1 of them'"
+	for _, statement := range plan.Statements {
+		if statement == want {
+			return
+		}
+	}
+	t.Errorf("expected %q in the plan, got\n%s", want, strings.Join(plan.Statements, "\n"))
+}
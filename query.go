@@ -0,0 +1,91 @@
+package oracle
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/schema"
+)
+
+func Query(db *gorm.DB) {
+	if db.Error == nil {
+		stmt := db.Statement
+		if stmt.Schema != nil && !stmt.Unscoped {
+			for _, c := range stmt.Schema.QueryClauses {
+				stmt.AddClause(c)
+			}
+		}
+
+		var lobFields []*schema.Field
+		if stmt.SQL.String() == "" {
+			selects := stmt.Selects
+			lobFields = selectColumnsOf(stmt)
+			callbacks.BuildQuerySQL(db)
+			stmt.Selects = selects
+		}
+
+		if !db.DryRun && db.Error == nil {
+			rows, err := stmt.ConnPool.QueryContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+			if err != nil {
+				db.AddError(err)
+				return
+			}
+			defer rows.Close()
+
+			gorm.Scan(rows, db, false)
+			if len(lobFields) > 0 && db.Error == nil {
+				setLOBLocators(db, lobFields)
+			}
+		}
+	}
+}
+
+// selectColumnsOf names the columns of a query into the model, where SELECT * would get them wrong: invisible columns
+// are left out of SELECT *, and LOB columns are left out of the query and returned, to be fetched by setLOBLocators
+// as long as the model has a primary key
+func selectColumnsOf(stmt *gorm.Statement) (lobFields []*schema.Field) {
+	if stmt.Schema == nil || len(stmt.Selects) > 0 || len(stmt.Joins) > 0 || !stmt.ReflectValue.IsValid() {
+		return nil
+	}
+
+	switch modelType := stmt.ReflectValue.Type(); modelType.Kind() {
+	case reflect.Slice, reflect.Array:
+		if modelType = modelType.Elem(); modelType.Kind() == reflect.Ptr {
+			modelType = modelType.Elem()
+		}
+		if modelType != stmt.Schema.ModelType {
+			return nil
+		}
+	case reflect.Struct:
+		if modelType != stmt.Schema.ModelType {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	var (
+		selectColumns, _ = stmt.SelectAndOmitColumns(false, false)
+		columns          = make([]string, 0, len(stmt.Schema.DBNames))
+		invisible        bool
+	)
+	for _, dbName := range stmt.Schema.DBNames {
+		if selected, ok := selectColumns[dbName]; ok && !selected {
+			continue
+		}
+
+		field := stmt.Schema.FieldsByDBName[dbName]
+		if field.IndirectFieldType == lobType && field.Readable && len(stmt.Schema.PrimaryFields) > 0 {
+			lobFields = append(lobFields, field)
+		} else {
+			columns = append(columns, dbName)
+		}
+		invisible = invisible || columnOptionOf(field).Invisible
+	}
+
+	if len(lobFields) > 0 || invisible {
+		stmt.Selects = columns
+	}
+	return lobFields
+}
@@ -0,0 +1,148 @@
+package oracle
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reservedRow has fields named after Oracle reserved words
+type reservedRow struct {
+	ID      uint
+	Level   int
+	Date    string
+	Comment string
+	Size    int
+}
+
+func TestReservedWordsAreQuoted(t *testing.T) {
+	db, _ := openFakeDB(t, Config{}, nil)
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	tests := []struct {
+		name string
+		run  func(tx *gorm.DB) *gorm.DB
+		want string
+	}{
+		{
+			name: "insert",
+			run: func(tx *gorm.DB) *gorm.DB {
+				return tx.Create(&reservedRow{Level: 1, Date: "today", Comment: "c", Size: 2})
+			},
+			want: `INSERT INTO RESERVED_ROWS ("LEVEL","DATE","COMMENT","SIZE") VALUES (:1,:2,:3,:4) RETURNING ID INTO :5`,
+		},
+		{
+			name: "update",
+			run: func(tx *gorm.DB) *gorm.DB {
+				return tx.Model(&reservedRow{ID: 1}).Updates(map[string]interface{}{"level": 2, "date": "today"})
+			},
+			want: `UPDATE RESERVED_ROWS SET "DATE"=:1,"LEVEL"=:2 WHERE ID = :3`,
+		},
+		{
+			name: "save",
+			run: func(tx *gorm.DB) *gorm.DB {
+				return tx.Save(&reservedRow{ID: 1, Level: 2})
+			},
+			want: `UPDATE RESERVED_ROWS SET "LEVEL"=:1,"DATE"=:2,"COMMENT"=:3,"SIZE"=:4 WHERE ID = :5`,
+		},
+		{
+			name: "select",
+			run: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(&reservedRow{Level: 3}).Order(clause.OrderByColumn{Column: clause.Column{Name: "date"}}).Find(&[]reservedRow{})
+			},
+			want: `SELECT * FROM RESERVED_ROWS WHERE RESERVED_ROWS."LEVEL" = :1 ORDER BY "DATE"`,
+		},
+		{
+			name: "delete",
+			run: func(tx *gorm.DB) *gorm.DB {
+				return tx.Where(&reservedRow{Level: 3, Size: 2}).Delete(&reservedRow{})
+			},
+			want: `DELETE FROM RESERVED_ROWS WHERE RESERVED_ROWS."LEVEL" = :1 AND RESERVED_ROWS."SIZE" = :2`,
+		},
+		{
+			name: "merge",
+			run: func(tx *gorm.DB) *gorm.DB {
+				return tx.Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"level", "date"})}).
+					Create(&reservedRow{ID: 1, Level: 1})
+			},
+			want: `MERGE INTO RESERVED_ROWS USING (SELECT :1 AS "LEVEL",:2 AS "DATE",:3 AS "COMMENT",:4 AS "SIZE",:5 AS ID FROM DUAL) excluded` +
+				` ON (RESERVED_ROWS.ID = excluded.ID)` +
+				` WHEN MATCHED THEN UPDATE SET "LEVEL"=excluded."LEVEL","DATE"=excluded."DATE"` +
+				` WHEN NOT MATCHED THEN INSERT ("LEVEL","DATE","COMMENT","SIZE",ID) VALUES (:6,:7,:8,:9,:10)`,
+		},
+		{
+			name: "merge without updates",
+			run: func(tx *gorm.DB) *gorm.DB {
+				return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reservedRow{ID: 1, Size: 1})
+			},
+			want: `MERGE INTO RESERVED_ROWS USING (SELECT :1 AS "LEVEL",:2 AS "DATE",:3 AS "COMMENT",:4 AS "SIZE",:5 AS ID FROM DUAL) excluded` +
+				` ON (RESERVED_ROWS.ID = excluded.ID)` +
+				` WHEN NOT MATCHED THEN INSERT ("LEVEL","DATE","COMMENT","SIZE",ID) VALUES (:6,:7,:8,:9,:10)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := test.run(dryRun)
+			if tx.Error != nil {
+				t.Fatalf("failed to build SQL: %v", tx.Error)
+			}
+			if got := tx.Statement.SQL.String(); got != test.want {
+				t.Errorf("got SQL\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHasColumnOfReservedWord(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+		return countResult(1)
+	})
+
+	for _, value := range []struct {
+		model interface{}
+		name  string
+	}{
+		{&reservedRow{}, "Level"},
+		{&reservedRow{}, "level"},
+		{"reserved_rows", "level"},
+	} {
+		fake.Reset()
+		if !db.Migrator().HasColumn(value.model, value.name) {
+			t.Errorf("expected column %s of %v to exist", value.name, value.model)
+		}
+
+		statements := fake.Statements()
+		if len(statements) != 1 {
+			t.Fatalf("expected a single query, got %q", fake.SQL())
+		}
+		if want := "SELECT COUNT(*) FROM USER_TAB_COLUMNS WHERE TABLE_NAME = :1 AND COLUMN_NAME = :2"; statements[0].SQL != want {
+			t.Errorf("got SQL %q, want %q", statements[0].SQL, want)
+		}
+		if want := []interface{}{"RESERVED_ROWS", "LEVEL"}; !reflect.DeepEqual(statements[0].Args, want) {
+			t.Errorf("got args %v, want %v", statements[0].Args, want)
+		}
+	}
+}
+
+func TestColumnsOfTableName(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+		return countResult(1)
+	})
+
+	if err := db.Migrator().DropColumn("reserved_rows", "level"); err != nil {
+		t.Fatalf("failed to drop column: %v", err)
+	}
+	if sql := fake.SQL(); sql[len(sql)-1] != `ALTER TABLE reserved_rows DROP "LEVEL"` {
+		t.Errorf("got %q", sql)
+	}
+
+	if err := db.Migrator().AlterColumn("reserved_rows", "level"); err == nil {
+		t.Errorf("expected AlterColumn to need a model")
+	}
+	if err := db.Migrator().AddColumn("reserved_rows", "level"); err == nil {
+		t.Errorf("expected AddColumn to need a model")
+	}
+}
@@ -1,28 +1,90 @@
 package oracle
 
 import (
+	"context"
+	"strings"
+	"sync"
+
 	"github.com/emirpasic/gods/sets/hashset"
 	"github.com/thoas/go-funk"
+	"gorm.io/gorm"
 )
 
-var ReservedWords = hashset.New(funk.Map(ReservedWordsList, func(s string) interface{} { return s }).([]interface{})...)
+var (
+	ReservedWords      = hashset.New(funk.Map(ReservedWordsList, func(s string) interface{} { return s }).([]interface{})...)
+	reservedWordsMutex sync.RWMutex
+)
 
+// IsReservedWord reports whether v, in any case, is a reserved word that must be quoted as an identifier
 func IsReservedWord(v string) bool {
-	return ReservedWords.Contains(v)
+	reservedWordsMutex.RLock()
+	defer reservedWordsMutex.RUnlock()
+	return ReservedWords.Contains(strings.ToUpper(v))
 }
 
-var ReservedWordsList = []string{
-	"AGGREGATE", "AGGREGATES", "ALL", "ALLOW", "ANALYZE", "ANCESTOR", "AND", "ANY", "AS", "ASC", "AT", "AVG", "BETWEEN",
-	"BINARY_DOUBLE", "BINARY_FLOAT", "BLOB", "BRANCH", "BUILD", "BY", "BYTE", "CASE", "CAST", "CHAR", "CHILD", "CLEAR",
-	"CLOB", "COMMIT", "COMPILE", "CONSIDER", "COUNT", "DATATYPE", "DATE", "DATE_MEASURE", "DAY", "DECIMAL", "DELETE",
-	"DESC", "DESCENDANT", "DIMENSION", "DISALLOW", "DIVISION", "DML", "ELSE", "END", "ESCAPE", "EXECUTE", "FIRST",
-	"FLOAT", "FOR", "FROM", "HIERARCHIES", "HIERARCHY", "HOUR", "IGNORE", "IN", "INFINITE", "INSERT", "INTEGER",
-	"INTERVAL", "INTO", "IS", "LAST", "LEAF_DESCENDANT", "LEAVES", "LEVEL", "LIKE", "LIKEC", "LIKE2", "LIKE4", "LOAD",
-	"LOCAL", "LOG_SPEC", "LONG", "MAINTAIN", "MAX", "MEASURE", "MEASURES", "MEMBER", "MEMBERS", "MERGE", "MLSLABEL",
-	"MIN", "MINUTE", "MODEL", "MONTH", "NAN", "NCHAR", "NCLOB", "NO", "NONE", "NOT", "NULL", "NULLS", "NUMBER",
-	"NVARCHAR2", "OF", "OLAP", "OLAP_DML_EXPRESSION", "ON", "ONLY", "OPERATOR", "OR", "ORDER", "OVER", "OVERFLOW",
-	"PARALLEL", "PARENT", "PLSQL", "PRUNE", "RAW", "RELATIVE", "ROOT_ANCESTOR", "ROWID", "SCN", "SECOND", "SELF",
-	"SERIAL", "SET", "SOLVE", "SOME", "SORT", "SPEC", "SUM", "SYNCH", "TEXT_MEASURE", "THEN", "TIME", "TIMESTAMP",
-	"TO", "UNBRANCH", "UPDATE", "USING", "VALIDATE", "VALUES", "VARCHAR2", "WHEN", "WHERE", "WITHIN", "WITH", "YEAR",
-	"ZERO", "ZONE",
+// RefreshReservedWords adds the reserved words of the connected server from V$RESERVED_WORDS,
+// which needs SELECT on the view, see Config.RefreshReservedWords to do it on open
+func RefreshReservedWords(db *gorm.DB) error {
+	ctx := context.Background()
+	if db.Statement != nil && db.Statement.Context != nil {
+		ctx = db.Statement.Context
+	}
+	return refreshReservedWords(ctx, db.ConnPool)
 }
+
+func refreshReservedWords(ctx context.Context, pool gorm.ConnPool) error {
+	rows, err := pool.QueryContext(ctx, "SELECT KEYWORD FROM V$RESERVED_WORDS WHERE RESERVED = 'Y'")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var keywords []interface{}
+	for rows.Next() {
+		var keyword string
+		if err := rows.Scan(&keyword); err != nil {
+			return err
+		}
+		keywords = append(keywords, strings.ToUpper(keyword))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	reservedWordsMutex.Lock()
+	defer reservedWordsMutex.Unlock()
+	ReservedWords.Add(keywords...)
+	return nil
+}
+
+// SQLReservedWords are the reserved words of Oracle SQL, unchanged from Oracle 7 through 23ai
+var SQLReservedWords = []string{
+	"ACCESS", "ADD", "ALL", "ALTER", "AND", "ANY", "AS", "ASC", "AUDIT", "BETWEEN", "BY", "CHAR", "CHECK", "CLUSTER",
+	"COLUMN", "COMMENT", "COMPRESS", "CONNECT", "CREATE", "CURRENT", "DATE", "DECIMAL", "DEFAULT", "DELETE", "DESC",
+	"DISTINCT", "DROP", "ELSE", "EXCLUSIVE", "EXISTS", "FILE", "FLOAT", "FOR", "FROM", "GRANT", "GROUP", "HAVING",
+	"IDENTIFIED", "IMMEDIATE", "IN", "INCREMENT", "INDEX", "INITIAL", "INSERT", "INTEGER", "INTERSECT", "INTO", "IS",
+	"LEVEL", "LIKE", "LOCK", "LONG", "MAXEXTENTS", "MINUS", "MLSLABEL", "MODE", "MODIFY", "NOAUDIT", "NOCOMPRESS",
+	"NOT", "NOWAIT", "NULL", "NUMBER", "OF", "OFFLINE", "ON", "ONLINE", "OPTION", "OR", "ORDER", "PCTFREE", "PRIOR",
+	"PRIVILEGES", "PUBLIC", "RAW", "RENAME", "RESOURCE", "REVOKE", "ROW", "ROWID", "ROWNUM", "ROWS", "SELECT",
+	"SESSION", "SET", "SHARE", "SIZE", "SMALLINT", "START", "SUCCESSFUL", "SYNONYM", "SYSDATE", "TABLE", "THEN", "TO",
+	"TRIGGER", "UID", "UNION", "UNIQUE", "UPDATE", "USER", "VALIDATE", "VALUES", "VARCHAR", "VARCHAR2", "VIEW",
+	"WHENEVER", "WHERE", "WITH",
+}
+
+// SQLReservedWords11g are the nested table pseudo columns Oracle reserves since 11g
+var SQLReservedWords11g = []string{
+	"COLUMN_VALUE", "NESTED_TABLE_ID",
+}
+
+// PLSQLReservedWords are the reserved words of PL/SQL, identifiers used in triggers and PL/SQL blocks must quote them
+var PLSQLReservedWords = []string{
+	"ALL", "ALTER", "AND", "ANY", "AS", "ASC", "AT", "BEGIN", "BETWEEN", "BY", "CASE", "CHECK", "CLUSTER", "CLUSTERS",
+	"COLAUTH", "COLUMNS", "COMPRESS", "CONNECT", "CRASH", "CREATE", "CURSOR", "DECLARE", "DEFAULT", "DESC",
+	"DISTINCT", "DROP", "ELSE", "END", "EXCEPTION", "EXCLUSIVE", "FETCH", "FOR", "FROM", "FUNCTION", "GOTO", "GRANT",
+	"GROUP", "HAVING", "IDENTIFIED", "IF", "IN", "INDEX", "INDEXES", "INSERT", "INTERSECT", "INTO", "IS", "LIKE",
+	"LOCK", "MINUS", "MODE", "NOCOMPRESS", "NOT", "NOWAIT", "NULL", "OF", "ON", "OPTION", "OR", "ORDER", "OVERLAPS",
+	"PROCEDURE", "PUBLIC", "RESOURCE", "REVOKE", "SELECT", "SHARE", "SIZE", "SQL", "START", "SUBTYPE", "TABAUTH",
+	"TABLE", "THEN", "TO", "TYPE", "UNION", "UNIQUE", "UPDATE", "VALUES", "VIEW", "VIEWS", "WHEN", "WHERE", "WITH",
+}
+
+var ReservedWordsList = funk.UniqString(append(append(append([]string{}, SQLReservedWords...), SQLReservedWords11g...), PLSQLReservedWords...))
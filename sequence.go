@@ -0,0 +1,285 @@
+package oracle
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// sequenceOf parses the `sequence` tag, e.g. `gorm:"sequence:ORDERS_SEQ,trigger"`, a bare `sequence` tag falls back
+// to SEQ_<TABLE> and a bare trigger to TRG_<TABLE>_<COLUMN>, or name it with `trigger:ORDERS_ID_TRG`.
+// Generated names longer than 30 bytes, the limit before 12.2, are shortened with a hash
+func sequenceOf(table string, field *schema.Field) (name string, trigger string, ok bool) {
+	setting, ok := field.TagSettings["SEQUENCE"]
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(setting, ",")
+	if name = strings.TrimSpace(parts[0]); name == "" || strings.EqualFold(name, "SEQUENCE") {
+		name = limitIdentifier("SEQ_" + table)
+	}
+
+	for _, option := range parts[1:] {
+		option = strings.TrimSpace(option)
+		if strings.EqualFold(option, "TRIGGER") {
+			trigger = limitIdentifier(fmt.Sprintf("TRG_%s_%s", table, field.DBName))
+		} else if len(option) > len("TRIGGER:") && strings.EqualFold(option[:len("TRIGGER:")], "TRIGGER:") {
+			trigger = strings.TrimSpace(option[len("TRIGGER:"):])
+		}
+	}
+	return
+}
+
+// withSequenceValues draws zero valued sequence columns from their sequence's NEXTVAL.
+// It returns the values that are still bound per row, the values clause to build the INSERT
+// with, and the sequence fields whose generated values have to be returned.
+func withSequenceValues(stmt *gorm.Statement, values clause.Values) (bound clause.Values, insert clause.Values, fields []*schema.Field) {
+	bound = values
+	var nextValues []interface{}
+	var nextColumns []clause.Column
+
+	for _, field := range stmt.Schema.Fields {
+		name, _, ok := sequenceOf(stmt.Table, field)
+		if !ok || field.DBName == "" {
+			continue
+		}
+
+		if idx := columnIndexOf(bound.Columns, field.DBName); idx >= 0 {
+			if !isZeroColumn(bound.Values, idx) {
+				continue
+			}
+			bound = withoutColumn(bound, idx)
+		}
+
+		nextColumns = append(nextColumns, clause.Column{Name: field.DBName})
+		nextValues = append(nextValues, clause.Expr{SQL: fmt.Sprintf("%s.NEXTVAL", name)})
+		fields = append(fields, field)
+	}
+
+	insert = clause.Values{
+		Columns: append(append([]clause.Column{}, bound.Columns...), nextColumns...),
+		Values:  [][]interface{}{append(append([]interface{}{}, bound.Values[0]...), nextValues...)},
+	}
+	return
+}
+
+func columnIndexOf(columns []clause.Column, name string) int {
+	for idx, column := range columns {
+		if column.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+func isZeroColumn(rows [][]interface{}, idx int) bool {
+	for _, row := range rows {
+		switch v := row[idx].(type) {
+		case nil, clause.Expr:
+		default:
+			if rv := reflect.ValueOf(v); !(rv.Kind() == reflect.Ptr && rv.IsNil()) && !rv.IsZero() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func withoutColumn(values clause.Values, idx int) clause.Values {
+	result := clause.Values{
+		Columns: append(append([]clause.Column{}, values.Columns[:idx]...), values.Columns[idx+1:]...),
+		Values:  make([][]interface{}, len(values.Values)),
+	}
+	for i, row := range values.Values {
+		result.Values[i] = append(append([]interface{}{}, row[:idx]...), row[idx+1:]...)
+	}
+	return result
+}
+
+func (m Migrator) createSequences(stmt *gorm.Statement) error {
+	for _, field := range stmt.Schema.Fields {
+		name, trigger, ok := sequenceOf(stmt.Table, field)
+		if !ok || field.DBName == "" {
+			continue
+		}
+
+		if !m.HasSequence(name) {
+			if err := m.CreateSequence(name, SequenceOption{}); err != nil {
+				return err
+			}
+		}
+
+		if trigger != "" {
+			if err := m.DB.Exec(fmt.Sprintf(
+				"CREATE OR REPLACE TRIGGER %s BEFORE INSERT ON %s FOR EACH ROW WHEN (NEW.%s IS NULL) BEGIN :NEW.%s := %s.NEXTVAL; END;",
+				trigger, stmt.Quote(stmt.Table), stmt.Quote(field.DBName), stmt.Quote(field.DBName), name,
+			)).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SequenceOption zero values leave the server default (on create) or the current setting (on alter) alone
+type SequenceOption struct {
+	StartWith   int64
+	IncrementBy int64
+	MinValue    int64
+	MaxValue    int64
+	Cache       int64
+	NoCache     bool
+	Cycle       bool
+	NoCycle     bool
+	// Restart resets the sequence to StartWith (or its MINVALUE) on AlterSequence, requires 18c+
+	Restart bool
+}
+
+func (option SequenceOption) build(alter bool) string {
+	var sql strings.Builder
+	if alter && option.Restart {
+		sql.WriteString(" RESTART")
+	}
+	if option.StartWith != 0 && (!alter || option.Restart) {
+		fmt.Fprintf(&sql, " START WITH %d", option.StartWith)
+	}
+	if option.IncrementBy != 0 {
+		fmt.Fprintf(&sql, " INCREMENT BY %d", option.IncrementBy)
+	}
+	if option.MinValue != 0 {
+		fmt.Fprintf(&sql, " MINVALUE %d", option.MinValue)
+	}
+	if option.MaxValue != 0 {
+		fmt.Fprintf(&sql, " MAXVALUE %d", option.MaxValue)
+	}
+	if option.NoCache {
+		sql.WriteString(" NOCACHE")
+	} else if option.Cache != 0 {
+		fmt.Fprintf(&sql, " CACHE %d", option.Cache)
+	}
+	if option.Cycle {
+		sql.WriteString(" CYCLE")
+	} else if option.NoCycle {
+		sql.WriteString(" NOCYCLE")
+	}
+	return sql.String()
+}
+
+func (m Migrator) CreateSequence(name string, option SequenceOption) error {
+	return m.DB.Exec("CREATE SEQUENCE ?"+option.build(false), clause.Table{Name: name}).Error
+}
+
+func (m Migrator) AlterSequence(name string, option SequenceOption) error {
+	alterSQL := option.build(true)
+	if alterSQL == "" {
+		return nil
+	}
+	return m.DB.Exec("ALTER SEQUENCE ?"+alterSQL, clause.Table{Name: name}).Error
+}
+
+func (m Migrator) DropSequence(name string) error {
+	return m.DB.Exec("DROP SEQUENCE ?", clause.Table{Name: name}).Error
+}
+
+// HasSequence looks in USER_SEQUENCES, or ALL_SEQUENCES for owner qualified names like HR.ORDERS_SEQ
+func (m Migrator) HasSequence(name string) bool {
+	var count int64
+	if owner, sequence := splitOwner(ConvertNameToFormat(name)); owner != "" {
+		m.DB.Raw(
+			"SELECT COUNT(*) FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = ? AND SEQUENCE_NAME = ?", owner, sequence,
+		).Row().Scan(&count)
+	} else {
+		m.DB.Raw("SELECT COUNT(*) FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?", sequence).Row().Scan(&count)
+	}
+	return count > 0
+}
+
+func (m Migrator) NextVal(name string) (value int64, err error) {
+	err = m.DB.Raw(fmt.Sprintf("SELECT %s.NEXTVAL FROM %s", name, m.Dialector.(Dialector).DummyTableName())).Row().Scan(&value)
+	return
+}
+
+func (m Migrator) CurrVal(name string) (value int64, err error) {
+	err = m.DB.Raw(fmt.Sprintf("SELECT %s.CURRVAL FROM %s", name, m.Dialector.(Dialector).DummyTableName())).Row().Scan(&value)
+	return
+}
+
+// SyncSequence moves the sequence or identity behind field past MAX(field), e.g. after bulk loads
+func (m Migrator) SyncSequence(value interface{}, field string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		column := field
+		if stmt.Schema != nil {
+			if f := stmt.Schema.LookUpField(field); f != nil {
+				column = f.DBName
+				if name, _, ok := sequenceOf(stmt.Table, f); ok {
+					return m.syncSequence(stmt, column, name)
+				}
+			}
+		}
+
+		identity, ok, err := m.identityColumnOf(stmt, column)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("failed to find sequence or identity for %s.%s", stmt.Table, column)
+		}
+
+		return m.DB.Exec(
+			fmt.Sprintf("ALTER TABLE ? MODIFY ? GENERATED %s AS IDENTITY (START WITH LIMIT VALUE)", identity.Generation),
+			clause.Table{Name: stmt.Table}, clause.Column{Name: column},
+		).Error
+	})
+}
+
+// syncSequence bumps the sequence with a temporary increment, which works on every version unlike RESTART
+func (m Migrator) syncSequence(stmt *gorm.Statement, column, name string) error {
+	var maxValue int64
+	if err := m.DB.Raw(
+		"SELECT COALESCE(MAX(?), 0) FROM ?", clause.Column{Name: column}, clause.Table{Name: stmt.Table},
+	).Row().Scan(&maxValue); err != nil {
+		return err
+	}
+
+	var increment int64
+	owner, sequence := splitOwner(ConvertNameToFormat(name))
+	if owner != "" {
+		if err := m.DB.Raw(
+			"SELECT INCREMENT_BY FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = ? AND SEQUENCE_NAME = ?", owner, sequence,
+		).Row().Scan(&increment); err != nil {
+			return err
+		}
+	} else if err := m.DB.Raw(
+		"SELECT INCREMENT_BY FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?", sequence,
+	).Row().Scan(&increment); err != nil {
+		return err
+	}
+
+	current, err := m.NextVal(name)
+	if err != nil {
+		return err
+	}
+
+	if gap := maxValue - current; gap > 0 {
+		if err := m.AlterSequence(name, SequenceOption{IncrementBy: gap}); err != nil {
+			return err
+		}
+		_, err = m.NextVal(name)
+		if restoreErr := m.AlterSequence(name, SequenceOption{IncrementBy: increment}); err == nil {
+			err = restoreErr
+		}
+	}
+	return err
+}
+
+func splitOwner(name string) (owner, object string) {
+	if idx := strings.Index(name, "."); idx >= 0 {
+		return name[:idx], name[idx+1:]
+	}
+	return "", name
+}
@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+type sequencedOrderWithAVeryLongTableName struct {
+	ID       uint `gorm:"sequence"`
+	Number   uint `gorm:"sequence:ORDER_NUMBERS,trigger"`
+	Position uint `gorm:"sequence:ORDER_POSITIONS,trigger:ORDER_POSITION_TRG"`
+}
+
+func TestSequenceOf(t *testing.T) {
+	parsed, err := schema.Parse(&sequencedOrderWithAVeryLongTableName{}, &sync.Map{}, Namer{})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		field, name, trigger string
+	}{
+		{"ID", "SEQ_SEQUENCED_ORDER_W_" + hashOf("SEQ_SEQUENCED_ORDER_WITH_A_VERY_LONG_TABLE_NAMES"), ""},
+		{"Number", "ORDER_NUMBERS", "TRG_SEQUENCED_ORDER_W_" + hashOf("TRG_SEQUENCED_ORDER_WITH_A_VERY_LONG_TABLE_NAMES_NUMBER")},
+		{"Position", "ORDER_POSITIONS", "ORDER_POSITION_TRG"},
+	}
+
+	for _, test := range tests {
+		name, trigger, ok := sequenceOf(parsed.Table, parsed.LookUpField(test.field))
+		if !ok || name != test.name || trigger != test.trigger {
+			t.Errorf("sequenceOf(%s) = %q, %q, %v, want %q, %q", test.field, name, trigger, ok, test.name, test.trigger)
+		}
+		if len(name) > maxIdentifierLength || len(trigger) > maxIdentifierLength {
+			t.Errorf("sequenceOf(%s) = %q, %q, longer than %d bytes", test.field, name, trigger, maxIdentifierLength)
+		}
+	}
+}
+
+func hashOf(name string) string {
+	return limitIdentifier(name)[maxIdentifierLength-8:]
+}
+
+func TestSyncSequenceOfTableName(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+		if hasPrefixFold(query, "SELECT i.GENERATION_TYPE") {
+			return fakeResult{
+				Columns: []string{"GENERATION_TYPE", "IDENTITY_OPTIONS", "DEFAULT_ON_NULL"},
+				Rows:    [][]driver.Value{{"BY DEFAULT", "START WITH: 1, INCREMENT BY: 1", "NO"}},
+			}
+		}
+		return fakeResult{}
+	})
+
+	if err := db.Migrator().(Migrator).SyncSequence("orders", "id"); err != nil {
+		t.Fatalf("failed to sync sequence: %v", err)
+	}
+
+	want := "ALTER TABLE orders MODIFY id GENERATED BY DEFAULT AS IDENTITY (START WITH LIMIT VALUE)"
+	if sql := fake.SQL(); sql[len(sql)-1] != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+}
@@ -0,0 +1,155 @@
+package oracle
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const storageOptionKey = "oracle:storage_options"
+
+// StorageOption holds the physical attributes applied to created tables, their indexes and LOB columns,
+// set it with Migrator.WithStorage or db.Set("oracle:storage_options", option)
+type StorageOption struct {
+	Tablespace string
+	PctFree    int
+	// Compress is BASIC, OLTP (COMPRESS FOR OLTP), ADVANCED (ROW STORE COMPRESS ADVANCED) or NOCOMPRESS
+	Compress string
+	// Logging is LOGGING or NOLOGGING
+	Logging  string
+	InMemory bool
+
+	IndexTablespace string
+
+	// LOBStorage is SECUREFILE or BASICFILE
+	LOBStorage    string
+	LOBTablespace string
+	// LOBCompress is LOW, MEDIUM or HIGH, SecureFiles only
+	LOBCompress    string
+	LOBDeduplicate bool
+}
+
+func (option StorageOption) validate() error {
+	if option.PctFree < 0 || option.PctFree > 99 {
+		return fmt.Errorf("invalid PCTFREE %d, expect 0 to 99", option.PctFree)
+	}
+
+	switch strings.ToUpper(option.Compress) {
+	case "", "BASIC", "OLTP", "ADVANCED", "NOCOMPRESS":
+	default:
+		return fmt.Errorf("invalid compression %q, expect BASIC, OLTP, ADVANCED or NOCOMPRESS", option.Compress)
+	}
+
+	switch strings.ToUpper(option.Logging) {
+	case "", "LOGGING", "NOLOGGING":
+	default:
+		return fmt.Errorf("invalid logging %q, expect LOGGING or NOLOGGING", option.Logging)
+	}
+
+	lobStorage := strings.ToUpper(option.LOBStorage)
+	switch lobStorage {
+	case "", "SECUREFILE", "BASICFILE":
+	default:
+		return fmt.Errorf("invalid LOB storage %q, expect SECUREFILE or BASICFILE", option.LOBStorage)
+	}
+
+	if option.LOBCompress != "" || option.LOBDeduplicate {
+		if lobStorage != "SECUREFILE" {
+			return fmt.Errorf("LOB compression and deduplication require SECUREFILE storage")
+		}
+		switch strings.ToUpper(option.LOBCompress) {
+		case "", "LOW", "MEDIUM", "HIGH":
+		default:
+			return fmt.Errorf("invalid LOB compression %q, expect LOW, MEDIUM or HIGH", option.LOBCompress)
+		}
+	}
+	return nil
+}
+
+func (option StorageOption) tableClause() (sql string) {
+	if option.Tablespace != "" {
+		sql += " TABLESPACE " + option.Tablespace
+	}
+	if option.PctFree > 0 {
+		sql += fmt.Sprintf(" PCTFREE %d", option.PctFree)
+	}
+	switch strings.ToUpper(option.Compress) {
+	case "BASIC":
+		sql += " COMPRESS BASIC"
+	case "OLTP":
+		sql += " COMPRESS FOR OLTP"
+	case "ADVANCED":
+		sql += " ROW STORE COMPRESS ADVANCED"
+	case "NOCOMPRESS":
+		sql += " NOCOMPRESS"
+	}
+	if option.Logging != "" {
+		sql += " " + strings.ToUpper(option.Logging)
+	}
+	if option.InMemory {
+		sql += " INMEMORY"
+	}
+	return
+}
+
+// lobClause builds LOB (col, ...) STORE AS SECUREFILE (...) for the LOB columns among fields
+func (option StorageOption) lobClause(m Migrator, stmt *gorm.Statement, fields ...*schema.Field) string {
+	if option.LOBStorage == "" && option.LOBTablespace == "" {
+		return ""
+	}
+
+	var columns []string
+	for _, field := range fields {
+		switch strings.ToUpper(m.DataTypeOf(field)) {
+		case "BLOB", "CLOB", "NCLOB":
+			columns = append(columns, stmt.Quote(field.DBName))
+		}
+	}
+	if len(columns) == 0 {
+		return ""
+	}
+
+	sql := " LOB (" + strings.Join(columns, ",") + ") STORE AS"
+	if option.LOBStorage != "" {
+		sql += " " + strings.ToUpper(option.LOBStorage)
+	}
+
+	var parameters []string
+	if option.LOBTablespace != "" {
+		parameters = append(parameters, "TABLESPACE "+option.LOBTablespace)
+	}
+	if option.LOBCompress != "" {
+		parameters = append(parameters, "COMPRESS "+strings.ToUpper(option.LOBCompress))
+	}
+	if option.LOBDeduplicate {
+		parameters = append(parameters, "DEDUPLICATE")
+	}
+	if len(parameters) > 0 {
+		sql += " (" + strings.Join(parameters, " ") + ")"
+	}
+	return sql
+}
+
+// WithStorage returns a Migrator that applies option to the tables, indexes and LOB columns it creates
+func (m Migrator) WithStorage(option StorageOption) Migrator {
+	return m.withSetting(storageOptionKey, option)
+}
+
+func (m Migrator) storageOption() (option StorageOption, ok bool, err error) {
+	value, ok := m.DB.Get(storageOptionKey)
+	if !ok {
+		return
+	}
+
+	switch v := value.(type) {
+	case StorageOption:
+		option = v
+	case *StorageOption:
+		option = *v
+	default:
+		return option, false, fmt.Errorf("invalid %s setting %T", storageOptionKey, value)
+	}
+	return option, true, option.validate()
+}
@@ -0,0 +1,176 @@
+package oracle
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TemporaryTabler is implemented by models whose tables are global or private temporary tables, e.g.
+//
+//	func (Staging) TemporaryTable() oracle.TemporaryTableOption {
+//		return oracle.TemporaryTableOption{OnCommit: oracle.OnCommitPreserveRows}
+//	}
+type TemporaryTabler interface {
+	TemporaryTable() TemporaryTableOption
+}
+
+// PrivateTemporaryTablePrefix is the default PRIVATE_TEMP_TABLE_PREFIX, private temporary table names must start with it
+const PrivateTemporaryTablePrefix = "ORA$PTT_"
+
+const (
+	OnCommitDeleteRows         = "DELETE ROWS"
+	OnCommitPreserveRows       = "PRESERVE ROWS"
+	OnCommitDropDefinition     = "DROP DEFINITION"
+	OnCommitPreserveDefinition = "PRESERVE DEFINITION"
+)
+
+type TemporaryTableOption struct {
+	// Private creates an 18c+ private temporary table, which lives in the session (or transaction) only
+	Private bool
+	// OnCommit is DELETE ROWS (default) or PRESERVE ROWS for global temporary tables,
+	// DROP DEFINITION (default) or PRESERVE DEFINITION for private ones
+	OnCommit string
+}
+
+func (option TemporaryTableOption) build(stmt *gorm.Statement) (string, error) {
+	onCommit := strings.ToUpper(option.OnCommit)
+	if option.Private {
+		if !isPrivateTemporaryTable(stmt.Table) {
+			return "", fmt.Errorf("private temporary table %s must be named with prefix %s", stmt.Table, PrivateTemporaryTablePrefix)
+		}
+
+		switch onCommit {
+		case "":
+			onCommit = OnCommitDropDefinition
+		case OnCommitDropDefinition, OnCommitPreserveDefinition:
+		default:
+			return "", fmt.Errorf("invalid ON COMMIT %q for private temporary table %s, expect DROP DEFINITION or PRESERVE DEFINITION", option.OnCommit, stmt.Table)
+		}
+		return "CREATE PRIVATE TEMPORARY TABLE ? (%s) ON COMMIT " + onCommit, nil
+	}
+
+	switch onCommit {
+	case "":
+		onCommit = OnCommitDeleteRows
+	case OnCommitDeleteRows, OnCommitPreserveRows:
+	default:
+		return "", fmt.Errorf("invalid ON COMMIT %q for temporary table %s, expect DELETE ROWS or PRESERVE ROWS", option.OnCommit, stmt.Table)
+	}
+	return "CREATE GLOBAL TEMPORARY TABLE ? (%s) ON COMMIT " + onCommit, nil
+}
+
+func isPrivateTemporaryTable(table string) bool {
+	return strings.HasPrefix(ConvertNameToFormat(table), PrivateTemporaryTablePrefix)
+}
+
+func temporaryTableOf(stmt *gorm.Statement) (option TemporaryTableOption, ok bool) {
+	if stmt.Schema == nil {
+		return
+	}
+	if tabler, isTemporary := reflect.New(stmt.Schema.ModelType).Interface().(TemporaryTabler); isTemporary {
+		return tabler.TemporaryTable(), true
+	}
+	return
+}
+
+// createTemporaryTable creates the table of stmt as a temporary table, temporary tables can't be partitioned
+// or reference other tables, and private ones take neither constraints nor indexes
+func (m Migrator) createTemporaryTable(value interface{}, stmt *gorm.Statement, option TemporaryTableOption) error {
+	createTableSQL, err := option.build(stmt)
+	if err != nil {
+		return err
+	}
+
+	var (
+		columns []string
+		values  = []interface{}{clause.Table{Name: stmt.Table}}
+	)
+
+	for _, dbName := range stmt.Schema.DBNames {
+		field := stmt.Schema.FieldsByDBName[dbName]
+		columns = append(columns, "? ?")
+		if option.Private {
+			columnSQL := m.DataTypeOf(field)
+			if field.NotNull {
+				columnSQL += " NOT NULL"
+			}
+			values = append(values, clause.Column{Name: dbName}, clause.Expr{SQL: columnSQL})
+		} else {
+			values = append(values, clause.Column{Name: dbName}, m.DB.Migrator().FullDataTypeOf(field))
+		}
+	}
+
+	if !option.Private {
+		if len(stmt.Schema.PrimaryFields) > 0 {
+			var primaryKeys []interface{}
+			for _, field := range stmt.Schema.PrimaryFields {
+				primaryKeys = append(primaryKeys, clause.Column{Name: field.DBName})
+			}
+			columns = append(columns, "PRIMARY KEY ?")
+			values = append(values, primaryKeys)
+		}
+
+		for _, chk := range sortedChecks(stmt) {
+			checkSQL, checkValues, err := m.checkConstraintOf(chk)
+			if err != nil {
+				return err
+			}
+			columns = append(columns, checkSQL)
+			values = append(values, checkValues...)
+		}
+	}
+
+	if err := m.DB.Exec(fmt.Sprintf(createTableSQL, strings.Join(columns, ",")), values...).Error; err != nil {
+		return err
+	}
+
+	if !option.Private {
+		for _, idx := range stmt.Schema.ParseIndexes() {
+			if err := m.DB.Migrator().CreateIndex(value, idx.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WithPrivateTemporaryTable creates a private temporary table for value on the transaction tx, runs fc and drops it,
+// value's table must be named with PrivateTemporaryTablePrefix
+//
+//	db.Transaction(func(tx *gorm.DB) error {
+//		return oracle.WithPrivateTemporaryTable(tx, &Staging{}, func(tx *gorm.DB) error {
+//			...
+//		})
+//	})
+func WithPrivateTemporaryTable(tx *gorm.DB, value interface{}, fc func(tx *gorm.DB) error) (err error) {
+	if committer, ok := tx.Statement.ConnPool.(gorm.TxCommitter); !ok || committer == nil || reflect.ValueOf(committer).IsNil() {
+		return fmt.Errorf("private temporary tables require a transaction, use db.Transaction or db.Begin")
+	}
+
+	m, ok := tx.Migrator().(Migrator)
+	if !ok {
+		return fmt.Errorf("private temporary tables require the oracle dialector")
+	}
+
+	if err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		option, ok := temporaryTableOf(stmt)
+		if !ok || !option.Private {
+			option = TemporaryTableOption{Private: true}
+		}
+		return m.createTemporaryTable(value, stmt, option)
+	}); err != nil {
+		return err
+	}
+
+	defer func() {
+		if dropErr := m.DropTable(value); err == nil {
+			err = dropErr
+		}
+	}()
+
+	return fc(tx)
+}
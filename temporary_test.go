@@ -0,0 +1,123 @@
+package oracle
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type stagedRow struct {
+	ID   uint
+	Name string `gorm:"size:100;not null;index"`
+}
+
+func (stagedRow) TemporaryTable() TemporaryTableOption {
+	return TemporaryTableOption{OnCommit: "preserve rows"}
+}
+
+type privateStagedRow struct {
+	ID   uint
+	Name string `gorm:"size:100;not null;index"`
+}
+
+func (privateStagedRow) TableName() string {
+	return "ORA$PTT_STAGED_ROWS"
+}
+
+func TestTemporaryTableOptionBuild(t *testing.T) {
+	db, _ := openFakeDB(t, Config{}, nil)
+
+	tests := []struct {
+		name   string
+		table  string
+		option TemporaryTableOption
+		want   string
+		err    string
+	}{
+		{"global", "STAGED_ROWS", TemporaryTableOption{}, "CREATE GLOBAL TEMPORARY TABLE ? (%s) ON COMMIT DELETE ROWS", ""},
+		{"global preserving rows", "STAGED_ROWS", TemporaryTableOption{OnCommit: "preserve rows"}, "CREATE GLOBAL TEMPORARY TABLE ? (%s) ON COMMIT PRESERVE ROWS", ""},
+		{"global with private ON COMMIT", "STAGED_ROWS", TemporaryTableOption{OnCommit: OnCommitDropDefinition}, "",
+			`invalid ON COMMIT "DROP DEFINITION" for temporary table STAGED_ROWS, expect DELETE ROWS or PRESERVE ROWS`},
+		{"private", "ORA$PTT_STAGED_ROWS", TemporaryTableOption{Private: true}, "CREATE PRIVATE TEMPORARY TABLE ? (%s) ON COMMIT DROP DEFINITION", ""},
+		{"private preserving definition", "ora$ptt_staged_rows", TemporaryTableOption{Private: true, OnCommit: OnCommitPreserveDefinition},
+			"CREATE PRIVATE TEMPORARY TABLE ? (%s) ON COMMIT PRESERVE DEFINITION", ""},
+		{"private with global ON COMMIT", "ORA$PTT_STAGED_ROWS", TemporaryTableOption{Private: true, OnCommit: OnCommitPreserveRows}, "",
+			`invalid ON COMMIT "PRESERVE ROWS" for private temporary table ORA$PTT_STAGED_ROWS, expect DROP DEFINITION or PRESERVE DEFINITION`},
+		{"private without prefix", "STAGED_ROWS", TemporaryTableOption{Private: true}, "",
+			"private temporary table STAGED_ROWS must be named with prefix ORA$PTT_"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.option.build(&gorm.Statement{DB: db, Table: test.table})
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Errorf("build() error = %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Errorf("build() = %q, %v, want %q", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestCreateGlobalTemporaryTable(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, nil)
+
+	if err := db.Migrator().CreateTable(&stagedRow{}); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	sql := fake.SQL()
+	if len(sql) != 2 ||
+		sql[0] != "CREATE GLOBAL TEMPORARY TABLE STAGED_ROWS (ID INTEGER,NAME VARCHAR2(100) NOT NULL,PRIMARY KEY (ID)) ON COMMIT PRESERVE ROWS" ||
+		!hasPrefixFold(sql[1], "CREATE INDEX IDX_STAGED_ROWS_NAME ON STAGED_ROWS") {
+		t.Errorf("got %q", sql)
+	}
+}
+
+func TestWithPrivateTemporaryTable(t *testing.T) {
+	db, fake := openFakeDB(t, Config{MaxStringSize: 4000}, nil)
+
+	if err := WithPrivateTemporaryTable(db, &privateStagedRow{}, func(tx *gorm.DB) error { return nil }); err == nil {
+		t.Errorf("expected private temporary tables to require a transaction")
+	}
+	if sql := fake.SQL(); len(sql) != 0 {
+		t.Errorf("expected no statements outside of a transaction, got %q", sql)
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return WithPrivateTemporaryTable(tx, &privateStagedRow{}, func(tx *gorm.DB) error {
+			return tx.Create(&privateStagedRow{Name: "staged"}).Error
+		})
+	}); err != nil {
+		t.Fatalf("failed to use private temporary table: %v", err)
+	}
+
+	sql := fake.SQL()
+	if len(sql) != 3 ||
+		sql[0] != "CREATE PRIVATE TEMPORARY TABLE ORA$PTT_STAGED_ROWS (ID INTEGER,NAME VARCHAR2(100) NOT NULL) ON COMMIT DROP DEFINITION" ||
+		!hasPrefixFold(sql[1], "INSERT INTO ORA$PTT_STAGED_ROWS") ||
+		sql[2] != "DROP TABLE ORA$PTT_STAGED_ROWS" {
+		t.Errorf("expected the table to be created, used and dropped, got %q", sql)
+	}
+}
+
+func TestHasPrivateTemporaryTableOfSession(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, func(query string, args []interface{}) fakeResult {
+		return countResult(1)
+	})
+
+	if !db.Migrator().HasTable("ora$ptt_staged_rows") {
+		t.Errorf("expected the private temporary table to exist")
+	}
+
+	statements := fake.Statements()
+	if len(statements) != 1 ||
+		statements[0].SQL != "SELECT COUNT(*) FROM USER_PRIVATE_TEMP_TABLES WHERE TABLE_NAME = :1 AND SID = SYS_CONTEXT('USERENV', 'SID')" ||
+		statements[0].Args[0] != "ORA$PTT_STAGED_ROWS" {
+		t.Errorf("expected the tables of this session only, got %+v", statements)
+	}
+}
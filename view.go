@@ -0,0 +1,75 @@
+package oracle
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ViewOption struct {
+	gorm.ViewOption
+	// Force creates the view even if its base tables don't exist yet
+	Force bool
+	// ReadOnly adds WITH READ ONLY, use CheckOption for WITH CHECK OPTION
+	ReadOnly bool
+}
+
+func (m Migrator) GetTables() (tableList []string, err error) {
+	err = m.DB.Raw(
+		"SELECT TABLE_NAME FROM USER_TABLES WHERE DROPPED = 'NO' AND NESTED = 'NO' AND SECONDARY = 'N' " +
+			"AND (IOT_TYPE IS NULL OR IOT_TYPE = 'IOT') AND TABLE_NAME NOT IN (SELECT MVIEW_NAME FROM USER_MVIEWS) " +
+			"ORDER BY TABLE_NAME",
+	).Scan(&tableList).Error
+	return
+}
+
+func (m Migrator) GetViews() (viewList []string, err error) {
+	err = m.DB.Raw("SELECT VIEW_NAME FROM USER_VIEWS ORDER BY VIEW_NAME").Scan(&viewList).Error
+	return
+}
+
+// HasView looks in USER_VIEWS, or ALL_VIEWS for owner qualified names like HR.ACTIVE_USERS
+func (m Migrator) HasView(name string) bool {
+	var count int64
+	if owner, view := splitOwner(ConvertNameToFormat(name)); owner != "" {
+		m.DB.Raw("SELECT COUNT(*) FROM ALL_VIEWS WHERE OWNER = ? AND VIEW_NAME = ?", owner, view).Row().Scan(&count)
+	} else {
+		m.DB.Raw("SELECT COUNT(*) FROM USER_VIEWS WHERE VIEW_NAME = ?", view).Row().Scan(&count)
+	}
+	return count > 0
+}
+
+func (m Migrator) CreateView(name string, option gorm.ViewOption) error {
+	return m.CreateViewWithOption(name, ViewOption{ViewOption: option})
+}
+
+func (m Migrator) CreateViewWithOption(name string, option ViewOption) error {
+	if option.Query == nil {
+		return fmt.Errorf("failed to create view %s, query is required", name)
+	}
+
+	createViewSQL := "CREATE "
+	if option.Replace {
+		createViewSQL += "OR REPLACE "
+	}
+	if option.Force {
+		createViewSQL += "FORCE "
+	}
+	createViewSQL += "VIEW ? AS ?"
+
+	if option.ReadOnly {
+		createViewSQL += " WITH READ ONLY"
+	} else if option.CheckOption != "" {
+		createViewSQL += " " + option.CheckOption
+	}
+
+	// DDL can't take bind variables, so the query is inlined
+	stmt := &gorm.Statement{DB: m.DB}
+	clause.Expr{SQL: createViewSQL, Vars: []interface{}{clause.Table{Name: name}, option.Query}}.Build(stmt)
+	return m.DB.Exec(inlineVars(stmt.SQL.String(), stmt.Vars...)).Error
+}
+
+func (m Migrator) DropView(name string) error {
+	return m.DB.Exec("DROP VIEW ?", clause.Table{Name: name}).Error
+}
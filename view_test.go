@@ -0,0 +1,46 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type viewUser struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time
+}
+
+func TestCreateViewInlinesLiterals(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, nil)
+
+	query := db.Model(&viewUser{}).Select("ID, NAME").
+		Where("NAME = ? AND CREATED_AT > ? AND NOTE <> '10:30'", "O'Brien", time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC))
+	if err := db.Migrator().CreateView("ACTIVE_USERS", gorm.ViewOption{Replace: true, Query: query}); err != nil {
+		t.Fatalf("failed to create view: %v", err)
+	}
+
+	want := "CREATE OR REPLACE VIEW ACTIVE_USERS AS SELECT ID, NAME FROM VIEW_USERS " +
+		"WHERE NAME = 'O''Brien' AND CREATED_AT > TIMESTAMP '2020-01-01 10:30:00 +00:00' AND NOTE <> '10:30'"
+	if statements := fake.Statements(); len(statements) != 1 || statements[0].SQL != want || len(statements[0].Args) != 0 {
+		t.Errorf("expected %q, got %+v", want, statements)
+	}
+}
+
+func TestCreateMaterializedViewInlinesLiterals(t *testing.T) {
+	db, fake := openFakeDB(t, Config{}, nil)
+
+	query := db.Model(&viewUser{}).Select("ID, NAME").
+		Where("NAME = ? AND CREATED_AT > ? AND NOTE <> '10:30'", "O'Brien", time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC))
+	if err := db.Migrator().(Migrator).CreateMaterializedView("USER_SNAPSHOT", MaterializedViewOption{Query: query, Refresh: "complete"}); err != nil {
+		t.Fatalf("failed to create materialized view: %v", err)
+	}
+
+	want := "CREATE MATERIALIZED VIEW USER_SNAPSHOT REFRESH COMPLETE AS SELECT ID, NAME FROM VIEW_USERS " +
+		"WHERE NAME = 'O''Brien' AND CREATED_AT > TIMESTAMP '2020-01-01 10:30:00 +00:00' AND NOTE <> '10:30'"
+	if statements := fake.Statements(); len(statements) != 1 || statements[0].SQL != want || len(statements[0].Args) != 0 {
+		t.Errorf("expected %q, got %+v", want, statements)
+	}
+}